@@ -6,6 +6,7 @@ import (
 
 	"github.com/faabiosr/cachego"
 	"github.com/faabiosr/cachego/mongo"
+	"go.mongodb.org/mongo-driver/bson"
 	mongo_client "go.mongodb.org/mongo-driver/mongo"
 	"log/slog"
 
@@ -18,11 +19,13 @@ type Cacher interface {
 	Set(ctx context.Context, key string, val []byte) error
 	SetWithExpiry(ctx context.Context, key string, val []byte, expiry time.Duration) error
 	Delete(ctx context.Context, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
 }
 
 type cache struct {
-	Client cachego.Cache
-	Logger *slog.Logger
+	Client     cachego.Cache
+	Collection *mongo_client.Collection
+	Logger     *slog.Logger
 }
 
 func NewCache(cfg *c.Conf, logger *slog.Logger, dbClient *mongo_client.Client) Cacher {
@@ -34,8 +37,9 @@ func NewCache(cfg *c.Conf, logger *slog.Logger, dbClient *mongo_client.Client) C
 
 	logger.Debug("cache initialized with mongodb as backend")
 	return &cache{
-		Client: c,
-		Logger: logger,
+		Client:     c,
+		Collection: cc,
+		Logger:     logger,
 	}
 }
 
@@ -78,3 +82,18 @@ func (s *cache) Delete(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// PurgeExpired function deletes the expired keys, such as the sessions of
+// users who never logged out, which are otherwise only removed when fetched.
+func (s *cache) PurgeExpired(ctx context.Context) (int64, error) {
+	// DEVELOPERS NOTE:
+	// The `cachego` library stores the expiry as a unix timestamp in the
+	// `duration` field where zero means the key never expires.
+	filter := bson.M{"duration": bson.M{"$gt": 0, "$lte": time.Now().Unix()}}
+	res, err := s.Collection.DeleteMany(ctx, filter)
+	if err != nil {
+		s.Logger.Error("cache purge expired failed", slog.Any("error", err))
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -0,0 +1,222 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// MinPartSize is the smallest part, except the last one, accepted by the
+	// object storage in a multipart upload.
+	MinPartSize = 5 << 20
+
+	// MaxPartCount is the maximum number of parts of a multipart upload.
+	MaxPartCount = 10000
+
+	// partMaxAttempts is the number of times a part is uploaded before the
+	// multipart upload is given up.
+	partMaxAttempts = 3
+
+	// partRetryDelay is the initial wait between attempts; the wait doubles
+	// after every failed attempt.
+	partRetryDelay = 500 * time.Millisecond
+)
+
+// CompletedPart represents a part uploaded in a multipart upload.
+type CompletedPart struct {
+	PartNumber int32  `bson:"part_number" json:"part_number"`
+	ETag       string `bson:"etag" json:"-"`
+	Size       int64  `bson:"size" json:"size"`
+}
+
+// CreateMultipartUpload function starts a multipart upload for the key and
+// returns its upload ID.
+func (s *objectStorager) CreateMultipartUpload(ctx context.Context, objectKey string) (string, error) {
+	params := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(objectKey),
+	}
+
+	// The following block of code will attach server side encryption if specified.
+	if s.SSECustomerKey != "" {
+		// Attach the server side encryption with customer key.
+		params.ServerSideEncryption = types.ServerSideEncryptionAwsKms
+		params.SSECustomerAlgorithm = aws.String("AES256") // SSE-C encryption algorithm
+		params.SSECustomerKey = &s.SSECustomerKey
+		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
+	}
+
+	out, err := s.S3Client.CreateMultipartUpload(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+// UploadPart function uploads the content as the part of the multipart upload
+// and retries the part if it fails. Parts are numbered from 1.
+func (s *objectStorager) UploadPart(ctx context.Context, objectKey string, uploadID string, partNumber int32, content []byte) (*CompletedPart, error) {
+	var err error
+	delay := partRetryDelay
+	for attempt := 1; attempt <= partMaxAttempts; attempt++ {
+		params := &s3.UploadPartInput{
+			Bucket:     aws.String(s.BucketName),
+			Key:        aws.String(objectKey),
+			UploadId:   aws.String(uploadID),
+			PartNumber: aws.Int32(partNumber),
+			Body:       bytes.NewReader(content),
+		}
+		if s.SSECustomerKey != "" {
+			params.SSECustomerAlgorithm = aws.String("AES256") // SSE-C encryption algorithm
+			params.SSECustomerKey = &s.SSECustomerKey
+			params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
+		}
+
+		var out *s3.UploadPartOutput
+		if out, err = s.S3Client.UploadPart(ctx, params); err == nil {
+			return &CompletedPart{
+				PartNumber: partNumber,
+				ETag:       aws.ToString(out.ETag),
+				Size:       int64(len(content)),
+			}, nil
+		}
+		s.Logger.Warn("object storage upload part attempt failed",
+			slog.String("object_key", objectKey),
+			slog.Any("part_number", partNumber),
+			slog.Int("attempt", attempt),
+			slog.Any("error", err))
+
+		if attempt == partMaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return nil, err
+}
+
+// CompleteMultipartUpload function assembles the parts, which must be ordered
+// by their number, into the object.
+func (s *objectStorager) CompleteMultipartUpload(ctx context.Context, objectKey string, uploadID string, parts []*CompletedPart) error {
+	completed := make([]types.CompletedPart, 0, len(parts))
+	for _, p := range parts {
+		completed = append(completed, types.CompletedPart{
+			ETag:       aws.String(p.ETag),
+			PartNumber: aws.Int32(p.PartNumber),
+		})
+	}
+	params := &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.BucketName),
+		Key:             aws.String(objectKey),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	}
+	if s.SSECustomerKey != "" {
+		params.SSECustomerAlgorithm = aws.String("AES256") // SSE-C encryption algorithm
+		params.SSECustomerKey = &s.SSECustomerKey
+		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
+	}
+
+	_, err := s.S3Client.CompleteMultipartUpload(ctx, params)
+	return err
+}
+
+// AbortMultipartUpload function discards the multipart upload and every part
+// uploaded so far. Aborting an upload which no longer exists is not an error.
+func (s *objectStorager) AbortMultipartUpload(ctx context.Context, objectKey string, uploadID string) error {
+	_, err := s.S3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.BucketName),
+		Key:      aws.String(objectKey),
+		UploadId: aws.String(uploadID),
+	})
+	var nsu *types.NoSuchUpload
+	if errors.As(err, &nsu) {
+		return nil
+	}
+	return err
+}
+
+// UploadContentFromReader function streams the content into the object
+// storage without holding more than a part in memory. Content smaller than a
+// part is uploaded in a single request, else a multipart upload is used where
+// every part is retried on its own. If reading the content or uploading fails
+// then the multipart upload is aborted so no partial object is left behind.
+func (s *objectStorager) UploadContentFromReader(ctx context.Context, objectKey string, r io.Reader, partSize int64) error {
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+
+	// Read the first part to see if a multipart upload is necessary.
+	buf := make([]byte, partSize)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return s.UploadContent(ctx, objectKey, buf[:n])
+	}
+	if err != nil {
+		return err
+	}
+
+	uploadID, err := s.CreateMultipartUpload(ctx, objectKey)
+	if err != nil {
+		return err
+	}
+	abort := func(cause error) error {
+		// Abort even if the request was cancelled, else the parts are kept
+		// in the bucket.
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		if err := s.AbortMultipartUpload(abortCtx, objectKey, uploadID); err != nil {
+			s.Logger.Error("failed aborting multipart upload",
+				slog.String("object_key", objectKey),
+				slog.String("upload_id", uploadID),
+				slog.Any("error", err))
+		}
+		return cause
+	}
+
+	parts := []*CompletedPart{}
+	for partNumber := int32(1); ; partNumber++ {
+		if partNumber > MaxPartCount {
+			return abort(errors.New("content exceeds the maximum number of parts"))
+		}
+		part, err := s.UploadPart(ctx, objectKey, uploadID, partNumber, buf[:n])
+		if err != nil {
+			return abort(err)
+		}
+		parts = append(parts, part)
+
+		n, err = io.ReadFull(r, buf)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			// Upload the last, smaller, part.
+			part, err := s.UploadPart(ctx, objectKey, uploadID, partNumber+1, buf[:n])
+			if err != nil {
+				return abort(err)
+			}
+			parts = append(parts, part)
+			break
+		}
+		if err != nil {
+			return abort(err)
+		}
+	}
+
+	if err := s.CompleteMultipartUpload(ctx, objectKey, uploadID, parts); err != nil {
+		return abort(err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"log"
 	"log/slog"
 	"mime/multipart"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -31,7 +32,13 @@ type ObjectStorager interface {
 	GenerateSSEC() (key, md5Hash string, err error)
 	UploadContent(ctx context.Context, objectKey string, content []byte) error
 	UploadContentFromMulipart(ctx context.Context, objectKey string, file multipart.File) error
+	UploadContentFromReader(ctx context.Context, objectKey string, r io.Reader, partSize int64) error
+	CreateMultipartUpload(ctx context.Context, objectKey string) (string, error)
+	UploadPart(ctx context.Context, objectKey string, uploadID string, partNumber int32, content []byte) (*CompletedPart, error)
+	CompleteMultipartUpload(ctx context.Context, objectKey string, uploadID string, parts []*CompletedPart) error
+	AbortMultipartUpload(ctx context.Context, objectKey string, uploadID string) error
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
+	ObjectExists(ctx context.Context, objectKey string) (bool, error)
 	GetDownloadablePresignedURL(ctx context.Context, key string, duration time.Duration) (string, error)
 	GetPresignedURL(ctx context.Context, key string, duration time.Duration) (string, error)
 	DeleteByKeys(ctx context.Context, key []string) error
@@ -40,9 +47,17 @@ type ObjectStorager interface {
 	GetBinaryData(ctx context.Context, objectKey string) (io.ReadCloser, error)
 	DownloadToLocalfile(ctx context.Context, objectKey string, filePath string) (string, error)
 	ListAllObjects(ctx context.Context) (*s3.ListObjectsOutput, error)
+	ListObjectsByPrefix(ctx context.Context, prefix string) ([]*ObjectSummary, error)
 	FindMatchingObjectKey(s3Objects *s3.ListObjectsOutput, partialKey string) string
 }
 
+// ObjectSummary represents an object stored in the bucket.
+type ObjectSummary struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
 type objectStorager struct {
 	S3Client              *s3.Client
 	PresignClient         *s3.PresignClient
@@ -225,7 +240,7 @@ func (s *objectStorager) GetDownloadablePresignedURL(ctx context.Context, key st
 		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
 	}
 
-	presignedUrl, err := s.PresignClient.PresignGetObject(context.Background(),
+	presignedUrl, err := s.PresignClient.PresignGetObject(ctx,
 		params,
 		s3.WithPresignExpires(duration))
 	if err != nil {
@@ -250,7 +265,7 @@ func (s *objectStorager) GetPresignedURL(ctx context.Context, objectKey string,
 		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
 	}
 
-	presignedUrl, err := s.PresignClient.PresignGetObject(context.Background(),
+	presignedUrl, err := s.PresignClient.PresignGetObject(ctx,
 		params,
 		s3.WithPresignExpires(duration))
 	if err != nil {
@@ -283,7 +298,7 @@ func (s *objectStorager) Cut(ctx context.Context, sourceObjectKey string, destin
 
 	params := &s3.CopyObjectInput{
 		Bucket:     aws.String(s.BucketName),
-		CopySource: aws.String(s.BucketName + "/" + sourceObjectKey),
+		CopySource: aws.String(copySource(s.BucketName, sourceObjectKey)),
 		Key:        aws.String(destinationObjectKey),
 	}
 
@@ -292,6 +307,12 @@ func (s *objectStorager) Cut(ctx context.Context, sourceObjectKey string, destin
 		params.SSECustomerAlgorithm = aws.String("AES256") // SSE-C encryption algorithm
 		params.SSECustomerKey = &s.SSECustomerKey
 		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
+
+		// The source object is encrypted with the same key so it must be
+		// provided to read it.
+		params.CopySourceSSECustomerAlgorithm = aws.String("AES256")
+		params.CopySourceSSECustomerKey = &s.SSECustomerKey
+		params.CopySourceSSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
 	}
 
 	_, copyErr := s.S3Client.CopyObject(ctx, params)
@@ -323,7 +344,7 @@ func (s *objectStorager) Copy(ctx context.Context, sourceObjectKey string, desti
 
 	params := &s3.CopyObjectInput{
 		Bucket:     aws.String(s.BucketName),
-		CopySource: aws.String(s.BucketName + "/" + sourceObjectKey),
+		CopySource: aws.String(copySource(s.BucketName, sourceObjectKey)),
 		Key:        aws.String(destinationObjectKey),
 	}
 
@@ -332,6 +353,12 @@ func (s *objectStorager) Copy(ctx context.Context, sourceObjectKey string, desti
 		params.SSECustomerAlgorithm = aws.String("AES256") // SSE-C encryption algorithm
 		params.SSECustomerKey = &s.SSECustomerKey
 		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
+
+		// The source object is encrypted with the same key so it must be
+		// provided to read it.
+		params.CopySourceSSECustomerAlgorithm = aws.String("AES256")
+		params.CopySourceSSECustomerKey = &s.SSECustomerKey
+		params.CopySourceSSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
 	}
 
 	_, copyErr := s.S3Client.CopyObject(ctx, params)
@@ -345,6 +372,30 @@ func (s *objectStorager) Copy(ctx context.Context, sourceObjectKey string, desti
 	return nil
 }
 
+// ObjectExists function returns true if an object was uploaded for the key.
+func (s *objectStorager) ObjectExists(ctx context.Context, objectKey string) (bool, error) {
+	params := &s3.HeadObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(objectKey),
+	}
+
+	// The following block of code will attach server side encryption if specified.
+	if s.SSECustomerKey != "" {
+		params.SSECustomerAlgorithm = aws.String("AES256") // SSE-C encryption algorithm
+		params.SSECustomerKey = &s.SSECustomerKey
+		params.SSECustomerKeyMD5 = &s.SSECustomerKeyMd5Hash
+	}
+
+	if _, err := s.S3Client.HeadObject(ctx, params); err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // GetBinaryData function will return the binary data for the particular key.
 func (s *objectStorager) GetBinaryData(ctx context.Context, objectKey string) (io.ReadCloser, error) {
 	params := &s3.GetObjectInput{
@@ -397,6 +448,32 @@ func (s *objectStorager) ListAllObjects(ctx context.Context) (*s3.ListObjectsOut
 	return objects, nil
 }
 
+// ListObjectsByPrefix function returns every object of the bucket whose key
+// starts with the prefix. Unlike `ListAllObjects` the results are not limited
+// to the first page returned by the bucket.
+func (s *objectStorager) ListObjectsByPrefix(ctx context.Context, prefix string) ([]*ObjectSummary, error) {
+	paginator := s3.NewListObjectsV2Paginator(s.S3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.BucketName),
+		Prefix: aws.String(prefix),
+	})
+
+	objects := []*ObjectSummary{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, &ObjectSummary{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
 // Function will iterate over all the s3 objects to match the partial key with
 // the actual key found in the S3 bucket.
 func (s *objectStorager) FindMatchingObjectKey(s3Objects *s3.ListObjectsOutput, partialKey string) string {
@@ -414,6 +491,19 @@ func (s *objectStorager) FindMatchingObjectKey(s3Objects *s3.ListObjectsOutput,
 }
 
 // calculateMD5Hash function to calculate MD5 hash of a byte slice
+// copySource function returns the URL-encoded copy source of the object as
+// expected by S3. Object keys may contain user provided filenames with spaces,
+// `+`, `#`, `?` or unicode characters.
+func copySource(bucketName string, objectKey string) string {
+	segments := strings.Split(objectKey, "/")
+	for i, segment := range segments {
+		// A `+` is left as is by the path escaping but may be decoded as
+		// a space.
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+	return bucketName + "/" + strings.Join(segments, "/")
+}
+
 func calculateMD5Hash(ssecKey string) string {
 	rawKey, err := base64.StdEncoding.DecodeString(ssecKey)
 	if err != nil {
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+// AuditEventController Interface for audit event business logic controller.
+type AuditEventController interface {
+	Record(ctx context.Context, req *AuditEventRecordRequestIDO) error
+	ListByFilter(ctx context.Context, f *auditevent_s.AuditEventListFilter) (*auditevent_s.AuditEventListResult, error)
+	ExportCSVByFilter(ctx context.Context, f *auditevent_s.AuditEventListFilter, w io.Writer) error
+}
+
+type AuditEventControllerImpl struct {
+	Config           *config.Conf
+	Logger           *slog.Logger
+	AuditEventStorer auditevent_s.AuditEventStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	ae_storer auditevent_s.AuditEventStorer,
+) AuditEventController {
+	s := &AuditEventControllerImpl{
+		Config:           appCfg,
+		Logger:           loggerp,
+		AuditEventStorer: ae_storer,
+	}
+	s.Logger.Debug("auditevent controller initialization started...")
+	s.Logger.Debug("auditevent controller initialized")
+	return s
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+)
+
+// exportPageSize is the number of events fetched at a time from the database
+// during the export.
+const exportPageSize = 500
+
+var exportHeader = []string{
+	"id",
+	"created_at",
+	"tenant_id",
+	"tenant_name",
+	"actor_user_id",
+	"actor_user_name",
+	"actor_user_role",
+	"ip_address",
+	"user_agent",
+	"action",
+	"target_type",
+	"target_id",
+	"target_name",
+	"changes",
+}
+
+// ExportCSVByFilter function will write every audit event matching the
+// filter as CSV, newest first. The changes are written as a JSON document.
+func (impl *AuditEventControllerImpl) ExportCSVByFilter(ctx context.Context, f *auditevent_s.AuditEventListFilter, w io.Writer) error {
+	if err := impl.authorizeReview(ctx); err != nil {
+		return err
+	}
+
+	// Apply protection based on ownership.
+	f.TenantID = policy.SessionTenantID(ctx) // Force tenant tenancy restrictions.
+	f.PageSize = exportPageSize
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for {
+		res, err := impl.AuditEventStorer.ListByFilter(ctx, f)
+		if err != nil {
+			impl.Logger.Error("database list by filter error", slog.Any("error", err))
+			return err
+		}
+		for _, e := range res.Results {
+			changes, err := json.Marshal(e.Changes)
+			if err != nil {
+				return err
+			}
+			if err := cw.Write([]string{
+				e.ID.Hex(),
+				e.CreatedAt.UTC().Format(time.RFC3339),
+				e.TenantID.Hex(),
+				escapeCSVCell(e.TenantName),
+				e.ActorUserID.Hex(),
+				escapeCSVCell(e.ActorUserName),
+				fmt.Sprintf("%d", e.ActorUserRole),
+				escapeCSVCell(e.IPAddress),
+				escapeCSVCell(e.UserAgent),
+				e.Action,
+				e.TargetType,
+				e.TargetID.Hex(),
+				escapeCSVCell(e.TargetName),
+				string(changes),
+			}); err != nil {
+				return err
+			}
+		}
+		if !res.HasNextPage {
+			break
+		}
+		f.Cursor = res.NextCursor
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeCSVCell function prefixes the text with a quote if it starts with a
+// character a spreadsheet would evaluate as a formula, so text entered by
+// users cannot run formulas when the export is opened.
+func escapeCSVCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
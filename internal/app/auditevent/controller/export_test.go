@@ -0,0 +1,20 @@
+package controller
+
+import "testing"
+
+func TestEscapeCSVCell(t *testing.T) {
+	for in, expected := range map[string]string{
+		"":                  "",
+		"Report.pdf":        "Report.pdf",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+1":                "'+1",
+		"-1":                "'-1",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\t=1":              "'\t=1",
+		"Mozilla/5.0 (X11)": "Mozilla/5.0 (X11)",
+	} {
+		if actual := escapeCSVCell(in); actual != expected {
+			t.Errorf("expected %q but received %q", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// authorizeReview returns a `403 Forbidden` error unless the authenticated
+// user is allowed to review the audit trail.
+func (impl *AuditEventControllerImpl) authorizeReview(ctx context.Context) error {
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
+	if userRole != user_s.UserRoleExecutive {
+		impl.Logger.Error("authenticated user is not executive role error",
+			slog.Any("role", userRole),
+			slog.Any("userID", userID))
+		return httperror.NewForForbiddenWithSingleField("message", "you role does not grant you access to this")
+	}
+	return nil
+}
+
+func (impl *AuditEventControllerImpl) ListByFilter(ctx context.Context, f *auditevent_s.AuditEventListFilter) (*auditevent_s.AuditEventListResult, error) {
+	if err := impl.authorizeReview(ctx); err != nil {
+		return nil, err
+	}
+
+	// Apply protection based on ownership.
+	f.TenantID = policy.SessionTenantID(ctx) // Force tenant tenancy restrictions.
+
+	res, err := impl.AuditEventStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+)
+
+// AuditEventRecordRequestIDO describes a mutation to record. `Before` and
+// `After` are the state of the target before and after the mutation and are
+// nil when the target was created or deleted respectively.
+type AuditEventRecordRequestIDO struct {
+	Action     string
+	TargetType string
+	TargetID   primitive.ObjectID
+	TargetName string
+	Before     interface{}
+	After      interface{}
+}
+
+// ignoredFields are never recorded as changes because they either change on
+// every mutation, are already a history of their own or must never be copied
+// into the audit trail.
+var ignoredFields = map[string]bool{
+	"versions":                 true,
+	"modified_at":              true,
+	"modified_by_user_id":      true,
+	"modified_by_user_name":    true,
+	"modified_from_ip_address": true,
+	"object_url":               true,
+	"email_verification_code":  true,
+	"password_hash":            true,
+	"password_hash_algorithm":  true,
+}
+
+// Snapshot returns a copy of the state of the target which is not affected
+// by the mutations made afterwards to the target. Use it to capture the
+// `Before` value of a record which will be modified in place.
+func Snapshot(v interface{}) map[string]interface{} {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// Diff returns the fields which are different between both states, sorted
+// by field name.
+func Diff(before, after interface{}) []*auditevent_s.AuditEventChange {
+	b := Snapshot(before)
+	a := Snapshot(after)
+
+	fields := make([]string, 0, len(b)+len(a))
+	seen := make(map[string]bool, len(b)+len(a))
+	for _, m := range []map[string]interface{}{b, a} {
+		for k := range m {
+			if !seen[k] && !ignoredFields[k] {
+				seen[k] = true
+				fields = append(fields, k)
+			}
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []*auditevent_s.AuditEventChange{}
+	for _, field := range fields {
+		if reflect.DeepEqual(b[field], a[field]) {
+			continue
+		}
+		changes = append(changes, &auditevent_s.AuditEventChange{
+			Field:  field,
+			Before: b[field],
+			After:  a[field],
+		})
+	}
+	return changes
+}
+
+// Record function will append an event for the mutation made by the
+// authenticated user to the audit trail.
+//
+// A failure to record the event must not fail the mutation; callers log the
+// error as a warning and carry on. Call it once the mutation is committed,
+// never from within a transaction, as a failed insert would abort the
+// transaction and with it the mutation.
+func (impl *AuditEventControllerImpl) Record(ctx context.Context, req *AuditEventRecordRequestIDO) error {
+	// Extract from our session the following data.
+	tid, _ := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	tenantName, _ := ctx.Value(constants.SessionUserTenantName).(string)
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName, _ := ctx.Value(constants.SessionUserName).(string)
+	userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
+	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+	userAgent, _ := ctx.Value(constants.SessionUserAgent).(string)
+
+	e := &auditevent_s.AuditEvent{
+		ID:            primitive.NewObjectID(),
+		TenantID:      tid,
+		TenantName:    tenantName,
+		ActorUserID:   userID,
+		ActorUserName: userName,
+		ActorUserRole: userRole,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		Action:        req.Action,
+		TargetType:    req.TargetType,
+		TargetID:      req.TargetID,
+		TargetName:    req.TargetName,
+		Changes:       Diff(req.Before, req.After),
+		CreatedAt:     time.Now(),
+	}
+	if err := impl.AuditEventStorer.Create(ctx, e); err != nil {
+		impl.Logger.Error("database create audit event error",
+			slog.String("action", req.Action),
+			slog.String("target_type", req.TargetType),
+			slog.Any("target_id", req.TargetID),
+			slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+type diffTestRecord struct {
+	Name         string    `json:"name"`
+	Status       int8      `json:"status"`
+	ModifiedAt   time.Time `json:"modified_at"`
+	PasswordHash string    `json:"password_hash"`
+}
+
+func TestDiffRecordsOnlyChangedFields(t *testing.T) {
+	r := &diffTestRecord{Name: "Intake forms", Status: 1, ModifiedAt: time.Now(), PasswordHash: "a"}
+	before := Snapshot(r)
+
+	r.Status = 2
+	r.ModifiedAt = r.ModifiedAt.Add(time.Hour)
+	r.PasswordHash = "b"
+
+	changes := Diff(before, r)
+	if len(changes) != 1 {
+		t.Fatalf("expected a single change but received %d", len(changes))
+	}
+	if changes[0].Field != "status" || changes[0].Before != float64(1) || changes[0].After != float64(2) {
+		t.Errorf("unexpected change %+v", changes[0])
+	}
+}
+
+func TestDiffOfCreatedRecord(t *testing.T) {
+	var missing *diffTestRecord
+	changes := Diff(missing, &diffTestRecord{Name: "Intake forms"})
+	if len(changes) != 2 {
+		t.Fatalf("expected name and status changes but received %d", len(changes))
+	}
+	if changes[0].Field != "name" || changes[0].Before != nil || changes[0].After != "Intake forms" {
+		t.Errorf("unexpected change %+v", changes[0])
+	}
+}
@@ -0,0 +1,124 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+const (
+	ActionCreate                = "create"
+	ActionUpdate                = "update"
+	ActionArchive               = "archive"
+	ActionDelete                = "delete"
+	ActionRevoke                = "revoke"
+	ActionRestore               = "restore"
+	ActionPurge                 = "purge"
+	ActionRestoreVersion        = "restore_version"
+	ActionGenerateShareableLink = "generate_shareable_link"
+	ActionCreateComment         = "create_comment"
+	ActionRevokeSessions        = "revoke_sessions"
+	ActionResetOTP              = "reset_otp"
+
+	TargetTypeSmartFolder   = "smart_folder"
+	TargetTypeObjectFile    = "object_file"
+	TargetTypeShareableLink = "shareable_link"
+	TargetTypeUser          = "user"
+	TargetTypeTenant        = "tenant"
+	TargetTypeStorageObject = "storage_object"
+)
+
+// AuditEvent represents a single mutation made by an authenticated user.
+// Records are append-only and are never modified nor deleted.
+type AuditEvent struct {
+	ID            primitive.ObjectID  `bson:"_id" json:"id"`
+	TenantID      primitive.ObjectID  `bson:"tenant_id" json:"tenant_id"`
+	TenantName    string              `bson:"tenant_name" json:"tenant_name"`
+	ActorUserID   primitive.ObjectID  `bson:"actor_user_id" json:"actor_user_id"`
+	ActorUserName string              `bson:"actor_user_name" json:"actor_user_name"`
+	ActorUserRole int8                `bson:"actor_user_role" json:"actor_user_role"`
+	IPAddress     string              `bson:"ip_address" json:"ip_address"`
+	UserAgent     string              `bson:"user_agent" json:"user_agent"`
+	Action        string              `bson:"action" json:"action"`
+	TargetType    string              `bson:"target_type" json:"target_type"`
+	TargetID      primitive.ObjectID  `bson:"target_id" json:"target_id"`
+	TargetName    string              `bson:"target_name" json:"target_name"`
+	Changes       []*AuditEventChange `bson:"changes" json:"changes"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// AuditEventChange represents the value of a single field before and after
+// the mutation.
+type AuditEventChange struct {
+	Field  string      `bson:"field" json:"field"`
+	Before interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+type AuditEventListFilter struct {
+	// Pagination related.
+	Cursor   primitive.ObjectID
+	PageSize int64
+
+	// Filter related.
+	TenantID     primitive.ObjectID
+	ActorUserID  primitive.ObjectID
+	Action       string
+	TargetType   string
+	TargetID     primitive.ObjectID
+	CreatedAtGTE time.Time
+	CreatedAtLTE time.Time
+}
+
+type AuditEventListResult struct {
+	Results     []*AuditEvent      `json:"results"`
+	NextCursor  primitive.ObjectID `json:"next_cursor"`
+	HasNextPage bool               `json:"has_next_page"`
+}
+
+// AuditEventStorer Interface for audit event.
+//
+// DEVELOPERS NOTE:
+// The audit trail must be immutable therefore do not add any update or
+// delete functions to this interface.
+type AuditEventStorer interface {
+	Create(ctx context.Context, m *AuditEvent) error
+	ListByFilter(ctx context.Context, f *AuditEventListFilter) (*AuditEventListResult, error)
+}
+
+type AuditEventStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) AuditEventStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("audit_events")
+
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "actor_user_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &AuditEventStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (impl AuditEventStorerImpl) ListByFilter(ctx context.Context, f *AuditEventListFilter) (*AuditEventListResult, error) {
+	// Create the filter based on the cursor. Events are always listed newest
+	// first so the cursor moves backwards through the `_id` values.
+	filter := bson.M{}
+	if !f.Cursor.IsZero() {
+		filter["_id"] = bson.M{"$lt": f.Cursor}
+	}
+
+	// Add filter conditions to the filter
+	if !f.TenantID.IsZero() {
+		filter["tenant_id"] = f.TenantID
+	}
+	if !f.ActorUserID.IsZero() {
+		filter["actor_user_id"] = f.ActorUserID
+	}
+	if f.Action != "" {
+		filter["action"] = f.Action
+	}
+	if f.TargetType != "" {
+		filter["target_type"] = f.TargetType
+	}
+	if !f.TargetID.IsZero() {
+		filter["target_id"] = f.TargetID
+	}
+	createdAt := bson.M{}
+	if !f.CreatedAtGTE.IsZero() {
+		createdAt["$gte"] = f.CreatedAtGTE
+	}
+	if !f.CreatedAtLTE.IsZero() {
+		createdAt["$lte"] = f.CreatedAtLTE
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+
+	impl.Logger.Debug("fetching audit events list",
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.Any("TenantID", f.TenantID),
+		slog.Any("ActorUserID", f.ActorUserID),
+		slog.String("Action", f.Action),
+		slog.String("TargetType", f.TargetType),
+		slog.Any("TargetID", f.TargetID),
+	)
+
+	// A page must hold at least one record else the page cannot be cut below.
+	pageSize := f.PageSize
+	if pageSize <= 0 {
+		pageSize = 25
+	}
+
+	// Fetch one more record then requested so we know if there is a next page.
+	options := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(pageSize + 1)
+
+	// Execute the query
+	cursor, err := impl.Collection.Find(ctx, filter, options)
+	if err != nil {
+		impl.Logger.Error("database find error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []*AuditEvent{}
+	if err := cursor.All(ctx, &results); err != nil {
+		impl.Logger.Error("database cursor decode error", slog.Any("error", err))
+		return nil, err
+	}
+
+	hasNextPage := false
+	nextCursor := primitive.NilObjectID
+	if int64(len(results)) > pageSize {
+		hasNextPage = true
+		results = results[:pageSize]
+		nextCursor = results[len(results)-1].ID
+	}
+
+	return &AuditEventListResult{
+		Results:     results,
+		NextCursor:  nextCursor,
+		HasNextPage: hasNextPage,
+	}, nil
+}
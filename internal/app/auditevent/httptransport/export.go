@@ -0,0 +1,35 @@
+package httptransport
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) ExportCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f, err := unmarshalListFilter(r.URL.Query())
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	// Write into a buffer first so errors can still be returned as JSON.
+	var buf bytes.Buffer
+	if err := h.Controller.ExportCSVByFilter(ctx, f, &buf); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf("audit-events-%s.csv", time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	if _, err := buf.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller auditevent_c.AuditEventController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c auditevent_c.AuditEventController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
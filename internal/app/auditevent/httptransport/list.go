@@ -0,0 +1,102 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// unmarshalListFilter function will extract the filter of the audit events
+// from the url parameters.
+func unmarshalListFilter(query url.Values) (*auditevent_s.AuditEventListFilter, error) {
+	f := &auditevent_s.AuditEventListFilter{
+		Cursor:     primitive.NilObjectID,
+		PageSize:   25,
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+	}
+
+	cursor := query.Get("cursor")
+	if cursor != "" {
+		cursor, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("cursor", "invalid value")
+		}
+		f.Cursor = cursor
+	}
+
+	pageSize := query.Get("page_size")
+	if pageSize != "" {
+		pageSize, _ := strconv.ParseInt(pageSize, 10, 64)
+		if pageSize <= 0 || pageSize > 250 {
+			pageSize = 250
+		}
+		f.PageSize = pageSize
+	}
+
+	actorUserID := query.Get("actor_user_id")
+	if actorUserID != "" {
+		actorUserID, err := primitive.ObjectIDFromHex(actorUserID)
+		if err != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("actor_user_id", "invalid value")
+		}
+		f.ActorUserID = actorUserID
+	}
+
+	targetID := query.Get("target_id")
+	if targetID != "" {
+		targetID, err := primitive.ObjectIDFromHex(targetID)
+		if err != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("target_id", "invalid value")
+		}
+		f.TargetID = targetID
+	}
+
+	createdAtGTE := query.Get("created_at_gte")
+	if createdAtGTE != "" {
+		createdAtGTE, err := time.Parse(time.RFC3339, createdAtGTE)
+		if err != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("created_at_gte", "invalid value")
+		}
+		f.CreatedAtGTE = createdAtGTE
+	}
+
+	createdAtLTE := query.Get("created_at_lte")
+	if createdAtLTE != "" {
+		createdAtLTE, err := time.Parse(time.RFC3339, createdAtLTE)
+		if err != nil {
+			return nil, httperror.NewForBadRequestWithSingleField("created_at_lte", "invalid value")
+		}
+		f.CreatedAtLTE = createdAtLTE
+	}
+
+	return f, nil
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f, err := unmarshalListFilter(r.URL.Query())
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.ListByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
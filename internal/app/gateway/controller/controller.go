@@ -14,9 +14,11 @@ import (
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/templatedemailer"
 	gateway_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/datastore"
 	howhear_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/datastore"
+	session_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/controller"
 	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	u_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/controller"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/jwt"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/kmutex"
@@ -32,6 +34,8 @@ type GatewayController interface {
 	Logout(ctx context.Context) error
 	ForgotPassword(ctx context.Context, email string) error
 	PasswordReset(ctx context.Context, code string, password string) error
+	VerifyEmail(ctx context.Context, code string) error
+	ResendVerificationEmail(ctx context.Context) error
 	Profile(ctx context.Context) (*user_s.User, error)
 	ProfileUpdate(ctx context.Context, nu *user_s.User) error
 	ProfileChangePassword(ctx context.Context, req *ProfileChangePasswordRequestIDO) error
@@ -42,6 +46,8 @@ type GatewayController interface {
 	VerifyOTP(ctx context.Context, req *VerificationTokenRequestIDO) (*VerificationTokenResponseIDO, error)
 	ValidateOTP(ctx context.Context, req *ValidateTokenRequestIDO) (*ValidateTokenResponseIDO, error)
 	DisableOTP(ctx context.Context) (*u_d.User, error)
+	GenerateOTPRecoveryCodes(ctx context.Context) (*OTPRecoveryCodesResponseIDO, error)
+	PurgeExpiredSessions(ctx context.Context) (int64, error)
 }
 
 type GatewayControllerImpl struct {
@@ -57,6 +63,8 @@ type GatewayControllerImpl struct {
 	UserStorer               user_s.UserStorer
 	TenantStorer             tenant_s.TenantStorer
 	HowHearAboutUsItemStorer howhear_s.HowHearAboutUsItemStorer
+	Session                  session_c.SessionController
+	UserToken                usertoken_c.UserTokenController
 }
 
 func NewController(
@@ -72,6 +80,8 @@ func NewController(
 	usr_storer user_s.UserStorer,
 	org_storer tenant_s.TenantStorer,
 	howhear_s howhear_s.HowHearAboutUsItemStorer,
+	sess_controller session_c.SessionController,
+	ut_controller usertoken_c.UserTokenController,
 ) GatewayController {
 	// loggerp.Debug("gateway controller initialization started...") // For debugging purposes only.
 	s := &GatewayControllerImpl{
@@ -87,6 +97,8 @@ func NewController(
 		UserStorer:               usr_storer,
 		TenantStorer:             org_storer,
 		HowHearAboutUsItemStorer: howhear_s,
+		Session:                  sess_controller,
+		UserToken:                ut_controller,
 	}
 	// s.Logger.Debug("gateway controller initialized")
 	if err := s.initializeAccounts(context.Background()); err != nil {
@@ -113,5 +125,6 @@ func (impl *GatewayControllerImpl) GetUserBySessionID(ctx context.Context, sessi
 		impl.Logger.Error("unmarshalling failed", slog.Any("err", err))
 		return nil, err
 	}
+	impl.Session.Touch(ctx, sessionID)
 	return &user, nil
 }
@@ -2,35 +2,51 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"time"
 
 	"log/slog"
 
-	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/controller"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
 )
 
+// passwordResetTokenExpiry is how long a password reset code works.
+const passwordResetTokenExpiry = time.Hour
+
+// ForgotPassword function emails a password reset code to the user. To not
+// disclose which email addresses have an account, it succeeds whether or not
+// the code was sent.
 func (impl *GatewayControllerImpl) ForgotPassword(ctx context.Context, email string) error {
 	// Defensive Code: For security purposes we need to remove all whitespaces from the email and lower the characters.
 	email = strings.ToLower(email)
 
-	// Lookup the user in our database, else return a `400 Bad Request` error.
+	// Lookup the user in our database.
 	u, err := impl.UserStorer.GetByEmail(ctx, email)
 	if err != nil {
 		impl.Logger.Error("database error", slog.Any("err", err))
 		return err
 	}
-	if u == nil {
-		impl.Logger.Warn("user does not exist validation error", slog.String("email", email))
-		return httperror.NewForBadRequestWithSingleField("email", "does not exist")
+	if u == nil || u.Status == user_s.UserStatusArchived {
+		impl.Logger.Warn("forgot password for unknown email")
+		return nil
 	}
 
-	// Generate unique token and save it to the user record.
-	u.EmailVerificationCode = impl.UUID.NewUUID()
-	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
-		impl.Logger.Warn("user update by id failed", slog.Any("error", err))
+	// Generate a code which is only valid for a short time.
+	code, err := impl.UserToken.Issue(ctx, u, usertoken_s.PurposePasswordReset, passwordResetTokenExpiry)
+	if errors.Is(err, usertoken_c.ErrRateLimited) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
 	// Send password reset email.
-	return impl.TemplatedEmailer.SendForgotPasswordEmail(email, u.EmailVerificationCode, u.FirstName)
+	if err := impl.TemplatedEmailer.SendForgotPasswordEmail(email, code, u.FirstName); err != nil {
+		impl.Logger.Error("failed sending forgot password email", slog.Any("user_id", u.ID), slog.Any("err", err))
+		return nil
+	}
+	return nil
 }
@@ -2,13 +2,13 @@ package controller
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 	"time"
 
 	"log/slog"
 
 	gateway_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
@@ -35,6 +35,12 @@ func (impl *GatewayControllerImpl) Login(ctx context.Context, email, password st
 		return nil, httperror.NewForBadRequestWithSingleField("password", "password do not match with record")
 	}
 
+	// Archived users lost their access.
+	if u.Status == user_s.UserStatusArchived {
+		impl.Logger.Warn("archived user login validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("email", "account was archived")
+	}
+
 	// // Enforce the verification code of the email.
 	// if u.WasEmailVerified == false {
 	// 	impl.Logger.Warn("email verification validation error", slog.Any("u", u))
@@ -53,22 +59,14 @@ func (impl *GatewayControllerImpl) Login(ctx context.Context, email, password st
 		}
 	}
 
-	uBin, err := json.Marshal(u)
-	if err != nil {
-		impl.Logger.Error("marshalling error", slog.Any("err", err))
-		return nil, err
-	}
-
 	// Set expiry duration.
 	atExpiry := 24 * time.Hour
 	rtExpiry := 14 * 24 * time.Hour
 
 	// Start our session using an access and refresh token.
-	sessionUUID := impl.UUID.NewUUID()
-
-	err = impl.Cache.SetWithExpiry(ctx, sessionUUID, uBin, rtExpiry)
+	sessionUUID, err := impl.Session.Create(ctx, u, rtExpiry)
 	if err != nil {
-		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		impl.Logger.Error("session create error", slog.Any("err", err))
 		return nil, err
 	}
 
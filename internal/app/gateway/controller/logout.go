@@ -11,8 +11,8 @@ func (impl *GatewayControllerImpl) Logout(ctx context.Context) error {
 	// Extract from our session the following data.
 	sessionID := ctx.Value(constants.SessionID).(string)
 
-	if err := impl.Cache.Delete(ctx, sessionID); err != nil {
-		impl.Logger.Error("cache delete error", slog.Any("err", err))
+	if err := impl.Session.Revoke(ctx, sessionID); err != nil {
+		impl.Logger.Error("session revoke error", slog.Any("err", err))
 		return err
 	}
 
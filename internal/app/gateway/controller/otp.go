@@ -151,6 +151,10 @@ type VerificationTokenRequestIDO struct {
 
 type VerificationTokenResponseIDO struct {
 	User *u_d.User `json:"user"`
+
+	// RecoveryCodes are shown to the user only once, the user must store them
+	// to regain access if they lose their device.
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 // VerifyOTP function verifies provided token from the third-party authenticator app. The purpose of this function is to finish the otp setup.
@@ -171,6 +175,13 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 	}
 	defer session.EndSession(ctx)
 
+	// Generate the recovery codes which are issued once 2FA is setup.
+	codes, hashes, err := generateOTPRecoveryCodes()
+	if err != nil {
+		impl.Logger.Error("failed generating otp recovery codes", slog.Any("err", err))
+		return nil, err
+	}
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 
@@ -217,6 +228,9 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 		// indicate the 2FA was successful.
 		u.OTPValidated = true
 
+		// Replace any recovery codes issued by a previous setup.
+		u.OTPRecoveryCodeHashes = hashes
+
 		// Keep track of when user's account changes.
 		u.ModifiedAt = time.Now()
 		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
@@ -251,8 +265,15 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 		return nil, err
 	}
 
+	// Require the other sessions of the user to validate their 2FA code.
+	if err := impl.Session.SyncByUserID(ctx, userID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return nil, err
+	}
+
 	res := &VerificationTokenResponseIDO{
-		User: u.(*u_d.User),
+		User:          u.(*u_d.User),
+		RecoveryCodes: codes,
 	}
 
 	return res, nil
@@ -260,6 +281,10 @@ func (impl *GatewayControllerImpl) VerifyOTP(ctx context.Context, req *Verificat
 
 type ValidateTokenRequestIDO struct {
 	Token string `json:"token"`
+
+	// RecoveryCode may be provided instead of the token if the user lost
+	// their device. Every recovery code can be used only once.
+	RecoveryCode string `json:"recovery_code"`
 }
 
 type ValidateTokenResponseIDO struct {
@@ -303,10 +328,21 @@ func (impl *GatewayControllerImpl) ValidateOTP(ctx context.Context, req *Validat
 		}
 
 		//
-		// STEP 1: Validate the inputted totp code.
+		// STEP 1: Validate the inputted totp code, or use up the inputted
+		//         recovery code.
 		//
 
-		if valid := totp.Validate(req.Token, u.OTPSecret); valid == false {
+		if req.Token == "" && req.RecoveryCode != "" {
+			remaining, ok := useOTPRecoveryCode(u.OTPRecoveryCodeHashes, req.RecoveryCode)
+			if !ok {
+				impl.Logger.Warn("otp recovery code invalid or used", slog.Any("user_id", u.ID))
+				return nil, httperror.NewForBadRequestWithSingleField("recovery_code", "expired or invalid")
+			}
+			impl.Logger.Info("otp recovery code used",
+				slog.Any("user_id", u.ID),
+				slog.Int("remaining", len(remaining)))
+			u.OTPRecoveryCodeHashes = remaining
+		} else if valid := totp.Validate(req.Token, u.OTPSecret); valid == false {
 
 			//
 			// STEP 2: Invalid tokens for whatever reason must return with error.
@@ -408,6 +444,7 @@ func (impl *GatewayControllerImpl) DisableOTP(ctx context.Context) (*u_d.User, e
 		u.OTPValidated = false
 		u.OTPSecret = ""
 		u.OTPAuthURL = ""
+		u.OTPRecoveryCodeHashes = nil
 		u.ModifiedAt = time.Now()
 		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
 			impl.Logger.Error("failed updating user", slog.Any("err", err))
@@ -441,5 +478,11 @@ func (impl *GatewayControllerImpl) DisableOTP(ctx context.Context) (*u_d.User, e
 		return nil, err
 	}
 
+	// Apply the change to the other sessions of the user.
+	if err := impl.Session.SyncByUserID(ctx, userID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return nil, err
+	}
+
 	return res.(*u_d.User), nil
 }
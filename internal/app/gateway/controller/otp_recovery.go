@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// otpRecoveryCodeCount is the number of recovery codes issued at once.
+const otpRecoveryCodeCount = 10
+
+type OTPRecoveryCodesResponseIDO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// generateOTPRecoveryCodes function returns new recovery codes, to be shown
+// to the user once, and their hashes to be saved instead of the codes.
+func generateOTPRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, otpRecoveryCodeCount)
+	hashes := make([]string, otpRecoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		s := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+		codes[i] = s[:5] + "-" + s[5:10]
+		hashes[i] = hashOTPRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashOTPRecoveryCode function returns the hash of the recovery code. The
+// code is normalized first so it may be entered in any case and without its
+// dash.
+func hashOTPRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// useOTPRecoveryCode function removes the recovery code from the hashes and
+// returns the remaining hashes. It returns false if the code does not match
+// any unused recovery code.
+func useOTPRecoveryCode(hashes []string, code string) ([]string, bool) {
+	h := hashOTPRecoveryCode(code)
+	for i, hash := range hashes {
+		if hash == h {
+			remaining := make([]string, 0, len(hashes)-1)
+			remaining = append(remaining, hashes[:i]...)
+			return append(remaining, hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
+
+// GenerateOTPRecoveryCodes function replaces the recovery codes of the
+// authenticated user with new ones. Codes issued earlier stop working.
+func (impl *GatewayControllerImpl) GenerateOTPRecoveryCodes(ctx context.Context) (*OTPRecoveryCodesResponseIDO, error) {
+	// Extract from our session the following data.
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	// Lookup the user in our database, else return a `400 Bad Request` error.
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("failed getting session user", slog.Any("err", err))
+		return nil, err
+	}
+	if u == nil {
+		impl.Logger.Warn("user does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if !u.OTPEnabled || !u.OTPVerified {
+		impl.Logger.Warn("user did not verify otp")
+		return nil, httperror.NewForBadRequestWithSingleField("message", "you did not setup two-factor authentication")
+	}
+
+	codes, hashes, err := generateOTPRecoveryCodes()
+	if err != nil {
+		impl.Logger.Error("failed generating otp recovery codes", slog.Any("err", err))
+		return nil, err
+	}
+	u.OTPRecoveryCodeHashes = hashes
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("failed updating user", slog.Any("err", err))
+		return nil, err
+	}
+	return &OTPRecoveryCodesResponseIDO{RecoveryCodes: codes}, nil
+}
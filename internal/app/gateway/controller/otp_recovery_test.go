@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOTPRecoveryCodesAreSingleUse(t *testing.T) {
+	codes, hashes, err := generateOTPRecoveryCodes()
+	if err != nil {
+		t.Fatalf("failed generating recovery codes: %v", err)
+	}
+	if len(codes) != otpRecoveryCodeCount || len(hashes) != otpRecoveryCodeCount {
+		t.Fatalf("expected %d recovery codes but received %d", otpRecoveryCodeCount, len(codes))
+	}
+	for i, code := range codes {
+		if hashes[i] == code {
+			t.Fatalf("expected recovery code to be stored hashed")
+		}
+	}
+
+	// Codes may be entered in any case and without their dash.
+	entered := strings.ToUpper(strings.ReplaceAll(codes[3], "-", ""))
+	remaining, ok := useOTPRecoveryCode(hashes, entered)
+	if !ok {
+		t.Fatalf("expected recovery code %q to be accepted", entered)
+	}
+	if len(remaining) != otpRecoveryCodeCount-1 {
+		t.Fatalf("expected %d remaining recovery codes but received %d", otpRecoveryCodeCount-1, len(remaining))
+	}
+	if _, ok := useOTPRecoveryCode(remaining, codes[3]); ok {
+		t.Fatal("expected used recovery code to be rejected")
+	}
+	if _, ok := useOTPRecoveryCode(remaining, codes[4]); !ok {
+		t.Fatal("expected unused recovery code to be accepted")
+	}
+	if _, ok := useOTPRecoveryCode(remaining, "aaaaa-aaaaa"); ok {
+		t.Fatal("expected unknown recovery code to be rejected")
+	}
+}
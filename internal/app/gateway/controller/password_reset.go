@@ -6,37 +6,81 @@ import (
 
 	"log/slog"
 
+	"go.mongodb.org/mongo-driver/mongo"
+
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 func (impl *GatewayControllerImpl) PasswordReset(ctx context.Context, code string, password string) error {
-	// Lookup the user in our database, else return a `400 Bad Request` error.
-	u, err := impl.UserStorer.GetByVerificationCode(ctx, code)
+	passwordHash, err := impl.Password.GenerateHashFromPassword(password)
 	if err != nil {
-		impl.Logger.Error("database error", slog.Any("err", err))
+		impl.Logger.Error("hashing error", slog.Any("error", err))
 		return err
 	}
-	if u == nil {
-		impl.Logger.Warn("user does not exist validation error")
-		return httperror.NewForBadRequestWithSingleField("code", "does not exist")
-	}
 
-	//TODO: Handle expiry dates.
+	////
+	//// Start the transaction.
+	////
 
-	passwordHash, err := impl.Password.GenerateHashFromPassword(password)
+	// DEVELOPERS NOTE:
+	// The code is used up together with the password change so the code
+	// still works if the password could not be saved.
+	session, err := impl.DbClient.StartSession()
 	if err != nil {
-		impl.Logger.Error("hashing error", slog.Any("error", err))
+		impl.Logger.Error("start session error", slog.Any("error", err))
 		return err
 	}
+	defer session.EndSession(ctx)
+
+	var u *user_s.User
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// Use up the code, else return a `400 Bad Request` error.
+		t, err := impl.UserToken.Consume(sessCtx, usertoken_s.PurposePasswordReset, code)
+		if err != nil {
+			return nil, err
+		}
+
+		// Lookup the user in our database, else return a `400 Bad Request` error.
+		u, err = impl.UserStorer.GetByID(sessCtx, t.UserID)
+		if err != nil {
+			impl.Logger.Error("database error", slog.Any("err", err))
+			return nil, err
+		}
+		// The code only resets the password of the email address it was
+		// emailed to.
+		if u == nil || u.Email != t.Email {
+			impl.Logger.Warn("user does not exist or changed email validation error", slog.Any("user_id", t.UserID))
+			return nil, httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
+		}
+
+		u.PasswordHash = passwordHash
+		u.PasswordHashAlgorithm = impl.Password.AlgorithmName()
+		u.ModifiedAt = time.Now()
+
+		if err := impl.UserStorer.UpdateByID(sessCtx, u); err != nil {
+			impl.Logger.Error("update error", slog.Any("err", err))
+			return nil, err
+		}
+
+		// Any other code emailed before the password changed stops working.
+		if err := impl.UserToken.InvalidateByUserID(sessCtx, u.ID, usertoken_s.PurposePasswordReset); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
 
-	u.PasswordHash = passwordHash
-	u.PasswordHashAlgorithm = impl.Password.AlgorithmName()
-	u.EmailVerificationCode = "" // Remove email active code so it cannot be used agian.
-	u.EmailVerificationExpiry = time.Now()
-	u.ModifiedAt = time.Now()
+	// Start a transaction
+	if _, err := session.WithTransaction(ctx, transactionFunc); err != nil {
+		impl.Logger.Error("session failed error", slog.Any("error", err))
+		return err
+	}
 
-	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
-		impl.Logger.Error("update error", slog.Any("err", err))
+	// Sign out every device as the password may have been reset because the
+	// account was compromised.
+	if _, err := impl.Session.RevokeOthersByUserID(ctx, u.ID, ""); err != nil {
+		impl.Logger.Error("session revoke others by user id error", slog.Any("err", err))
 		return err
 	}
 
@@ -8,6 +8,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
@@ -62,6 +63,11 @@ func (impl *GatewayControllerImpl) ProfileUpdate(ctx context.Context, nu *user_s
 	ou.LastName = nu.LastName
 	ou.Name = fmt.Sprintf("%s %s", nu.FirstName, nu.LastName)
 	ou.LexicalName = fmt.Sprintf("%s, %s", nu.LastName, nu.FirstName)
+	emailChanged := ou.Email != nu.Email
+	if emailChanged {
+		// The new email address must be verified again.
+		ou.WasEmailVerified = false
+	}
 	ou.Email = nu.Email
 	ou.Phone = nu.Phone
 	ou.Country = nu.Country
@@ -85,6 +91,23 @@ func (impl *GatewayControllerImpl) ProfileUpdate(ctx context.Context, nu *user_s
 		impl.Logger.Error("user update by id error", slog.Any("error", err))
 		return err
 	}
+	if emailChanged {
+		// Codes emailed to the previous email address stop working.
+		for _, purpose := range []string{usertoken_s.PurposeEmailVerification, usertoken_s.PurposePasswordReset} {
+			if err := impl.UserToken.InvalidateByUserID(ctx, ou.ID, purpose); err != nil {
+				return err
+			}
+		}
+		if err := impl.UserToken.SendVerificationEmail(ctx, ou); err != nil {
+			impl.Logger.Error("failed sending verification email with error from profile update",
+				slog.Any("err", err),
+				slog.Any("UserID", ou.ID))
+		}
+	}
+	if err := impl.Session.SyncByUserID(ctx, ou.ID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return err
+	}
 	return nil
 }
 
@@ -154,5 +177,21 @@ func (impl *GatewayControllerImpl) ProfileChangePassword(ctx context.Context, re
 		impl.Logger.Error("user update by id error", slog.Any("error", err))
 		return err
 	}
+
+	// Any password reset code emailed before stops working.
+	if err := impl.UserToken.InvalidateByUserID(ctx, u.ID, usertoken_s.PurposePasswordReset); err != nil {
+		return err
+	}
+
+	// Sign out the other devices but keep the user signed in on this one.
+	sessionID, _ := ctx.Value(constants.SessionID).(string)
+	if _, err := impl.Session.RevokeOthersByUserID(ctx, u.ID, sessionID); err != nil {
+		impl.Logger.Error("session revoke others by user id error", slog.Any("error", err))
+		return err
+	}
+	if err := impl.Session.SyncByUserID(ctx, u.ID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return err
+	}
 	return nil
 }
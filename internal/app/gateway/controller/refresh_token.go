@@ -2,13 +2,13 @@ package controller
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
+	"net/http"
 	"time"
 
 	"log/slog"
 
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 func (impl *GatewayControllerImpl) RefreshToken(ctx context.Context, value string) (*user_s.User, string, time.Time, string, time.Time, error) {
@@ -19,24 +19,21 @@ func (impl *GatewayControllerImpl) RefreshToken(ctx context.Context, value strin
 	sessionID, err := impl.JWT.ProcessJWTToken(value)
 	if err != nil {
 		impl.Logger.Warn("process jwt refresh token does not exist", slog.String("value", value))
-		err := errors.New("jwt refresh token failed")
+		err := httperror.NewForSingleField(http.StatusUnauthorized, "value", "jwt refresh token failed")
 		return nil, "", time.Now(), "", time.Now(), err
 	}
 
 	////
-	//// Lookup in our in-memory the user record for the `sessionID` or error.
+	//// Exchange the session for a new session; the refresh token cannot be
+	//// used again afterwards.
 	////
 
-	uBin, err := impl.Cache.Get(ctx, sessionID)
-	if err != nil {
-		impl.Logger.Error("in-memory set error", slog.Any("err", err))
-		return nil, "", time.Now(), "", time.Now(), err
-	}
+	// Set expiry duration.
+	atExpiry := 24 * time.Hour
+	rtExpiry := 14 * 24 * time.Hour
 
-	var u *user_s.User
-	err = json.Unmarshal(uBin, &u)
+	u, newSessionUUID, err := impl.Session.Rotate(ctx, sessionID, rtExpiry)
 	if err != nil {
-		impl.Logger.Error("unmarshal error", slog.Any("err", err))
 		return nil, "", time.Now(), "", time.Now(), err
 	}
 
@@ -44,19 +41,6 @@ func (impl *GatewayControllerImpl) RefreshToken(ctx context.Context, value strin
 	//// Generate new access and refresh tokens and return them.
 	////
 
-	// Set expiry duration.
-	atExpiry := 24 * time.Hour
-	rtExpiry := 14 * 24 * time.Hour
-
-	// Start our session using an access and refresh token.
-	newSessionUUID := impl.UUID.NewUUID()
-
-	err = impl.Cache.SetWithExpiry(ctx, newSessionUUID, uBin, rtExpiry)
-	if err != nil {
-		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
-		return nil, "", time.Now(), "", time.Now(), err
-	}
-
 	// Generate our JWT token.
 	accessToken, accessTokenExpiry, refreshToken, refreshTokenExpiry, err := impl.JWT.GenerateJWTTokenPair(newSessionUUID, atExpiry, rtExpiry)
 	if err != nil {
@@ -96,17 +96,18 @@ func (impl *GatewayControllerImpl) UserRegister(ctx context.Context, req *UserRe
 	defer session.EndSession(ctx)
 
 	// Define a transaction function with a series of operations
+	var u *user_s.User
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 
 		// Lookup the user in our database, else return a `400 Bad Request` error.
-		u, err := impl.UserStorer.GetByEmail(sessCtx, req.Email)
+		eu, err := impl.UserStorer.GetByEmail(sessCtx, req.Email)
 		if err != nil {
 			impl.Logger.Error("database error",
 				slog.Any("err", err),
 				slog.String("Email", req.Email))
 			return nil, err
 		}
-		if u != nil {
+		if eu != nil {
 			impl.Logger.Warn("user already exists validation error",
 				slog.String("Email", req.Email))
 			return nil, httperror.NewForBadRequestWithSingleField("email", "email is not unique")
@@ -117,16 +118,6 @@ func (impl *GatewayControllerImpl) UserRegister(ctx context.Context, req *UserRe
 		if err != nil {
 			return nil, err
 		}
-
-		// // Send our verification email.
-		// if err := impl.TemplatedEmailer.SendMemberVerificationEmail(u.Email, u.EmailVerificationCode, u.FirstName, u.TenantName); err != nil {
-		// 	impl.Logger.Error("failed sending verification email with error from registration",
-		// 		slog.Any("err", err),
-		// 		slog.String("Email", u.Email),
-		// 		slog.Any("UserID", u.ID))
-		// 	// Do not send error message to user nor abort the registration process.
-		// 	// Just simply log an error message and continue.
-		// }
 		return nil, nil
 	}
 
@@ -137,6 +128,16 @@ func (impl *GatewayControllerImpl) UserRegister(ctx context.Context, req *UserRe
 		return nil, err
 	}
 
+	// Send our verification email once the user exists.
+	if err := impl.UserToken.SendVerificationEmail(ctx, u); err != nil {
+		impl.Logger.Error("failed sending verification email with error from registration",
+			slog.Any("err", err),
+			slog.String("Email", u.Email),
+			slog.Any("UserID", u.ID))
+		// Do not send error message to user nor abort the registration process.
+		// Just simply log an error message and continue, the user may resend it.
+	}
+
 	return impl.Login(ctx, req.Email, req.Password)
 }
 
@@ -203,44 +204,42 @@ func (impl *GatewayControllerImpl) createUserForRequest(sessCtx mongo.SessionCon
 	//
 
 	u := &user_s.User{
-		TenantID:                tenant.ID,
-		TenantName:              tenant.Name,
-		ID:                      userID,
-		FirstName:               req.FirstName,
-		LastName:                req.LastName,
-		Name:                    fmt.Sprintf("%s %s", req.FirstName, req.LastName),
-		LexicalName:             fmt.Sprintf("%s, %s", req.LastName, req.FirstName),
-		Email:                   req.Email,
-		PasswordHash:            passwordHash,
-		PasswordHashAlgorithm:   impl.Password.AlgorithmName(),
-		Role:                    user_s.UserRoleCustomer,
-		Phone:                   req.Phone,
-		Country:                 req.Country,
-		Region:                  req.Region,
-		City:                    req.City,
-		PostalCode:              req.PostalCode,
-		AddressLine1:            req.AddressLine1,
-		AgreeTOS:                req.AgreeTOS,
-		TOSVersion:              "January, 2024",
-		TOSText:                 "XXX",
-		TOSAgreedOn:             time.Now().In(location),
-		PrivacyVersion:          "January, 2024",
-		PrivacyText:             "yyy",
-		PrivacyAgreedOn:         time.Now().In(location),
-		AgreePromotionsEmail:    req.AgreePromotionsEmail,
-		AgreeWaiver:             req.AgreeWaiver,
-		WaiverText:              "",
-		WaiverAgreedOn:          time.Now().In(location),
-		CreatedByUserID:         userID,
-		CreatedAt:               time.Now().In(location),
-		CreatedByUserName:       fmt.Sprintf("%s %s", req.FirstName, req.LastName),
-		ModifiedByUserID:        userID,
-		ModifiedAt:              time.Now().In(location),
-		ModifiedByUserName:      fmt.Sprintf("%s %s", req.FirstName, req.LastName),
-		WasEmailVerified:        false,
-		EmailVerificationCode:   impl.UUID.NewUUID(),
-		EmailVerificationExpiry: time.Now().Add(72 * time.Hour),
-		Status:                  user_s.UserStatusActive,
+		TenantID:              tenant.ID,
+		TenantName:            tenant.Name,
+		ID:                    userID,
+		FirstName:             req.FirstName,
+		LastName:              req.LastName,
+		Name:                  fmt.Sprintf("%s %s", req.FirstName, req.LastName),
+		LexicalName:           fmt.Sprintf("%s, %s", req.LastName, req.FirstName),
+		Email:                 req.Email,
+		PasswordHash:          passwordHash,
+		PasswordHashAlgorithm: impl.Password.AlgorithmName(),
+		Role:                  user_s.UserRoleCustomer,
+		Phone:                 req.Phone,
+		Country:               req.Country,
+		Region:                req.Region,
+		City:                  req.City,
+		PostalCode:            req.PostalCode,
+		AddressLine1:          req.AddressLine1,
+		AgreeTOS:              req.AgreeTOS,
+		TOSVersion:            "January, 2024",
+		TOSText:               "XXX",
+		TOSAgreedOn:           time.Now().In(location),
+		PrivacyVersion:        "January, 2024",
+		PrivacyText:           "yyy",
+		PrivacyAgreedOn:       time.Now().In(location),
+		AgreePromotionsEmail:  req.AgreePromotionsEmail,
+		AgreeWaiver:           req.AgreeWaiver,
+		WaiverText:            "",
+		WaiverAgreedOn:        time.Now().In(location),
+		CreatedByUserID:       userID,
+		CreatedAt:             time.Now().In(location),
+		CreatedByUserName:     fmt.Sprintf("%s %s", req.FirstName, req.LastName),
+		ModifiedByUserID:      userID,
+		ModifiedAt:            time.Now().In(location),
+		ModifiedByUserName:    fmt.Sprintf("%s %s", req.FirstName, req.LastName),
+		WasEmailVerified:      false,
+		Status:                user_s.UserStatusActive,
 		// PaymentProcessorName:       b.PaymentProcessorName, // Attach the required payment process
 		// PaymentProcessorCustomerID: *paymentProcessorCustomerID,
 		HasShippingAddress:       req.HasShippingAddress,
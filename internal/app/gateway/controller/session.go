@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+)
+
+// PurgeExpiredSessions function deletes the sessions of every user which
+// expired without the user logging out.
+func (impl *GatewayControllerImpl) PurgeExpiredSessions(ctx context.Context) (int64, error) {
+	count, err := impl.Cache.PurgeExpired(ctx)
+	if err != nil {
+		impl.Logger.Error("cache purge expired error", slog.Any("err", err))
+		return 0, err
+	}
+	registered, err := impl.Session.PurgeExpired(ctx)
+	if err != nil {
+		impl.Logger.Error("session purge expired error", slog.Any("err", err))
+		return 0, err
+	}
+	impl.Logger.Debug("purged expired registered sessions", slog.Int64("count", registered))
+	return count, nil
+}
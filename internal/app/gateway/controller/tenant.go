@@ -2,21 +2,22 @@ package controller
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log/slog"
 
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 type ExecutiveVisitsTenantRequest struct {
 	TenantID primitive.ObjectID `json:"tenant_id,omitempty"`
 }
 
+// ExecutiveVisitsTenant function scopes the session of the executive to the
+// tenant so the executive may work on the tenant's records. The executive
+// keeps using the same session and tokens.
 func (impl *GatewayControllerImpl) ExecutiveVisitsTenant(ctx context.Context, req *ExecutiveVisitsTenantRequest) error {
 	////
 	//// Extract the `sessionID` so we can process it.
@@ -27,44 +28,25 @@ func (impl *GatewayControllerImpl) ExecutiveVisitsTenant(ctx context.Context, re
 
 	if userRole != user_s.UserRoleExecutive {
 		impl.Logger.Error("not executive error", slog.Int("role", int(userRole)))
-		return errors.New("user is not executive")
+		return httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
 	}
 
-	////
-	//// Lookup in our in-memory the user record for the `sessionID` or error.
-	////
-
-	uBin, err := impl.Cache.Get(ctx, sessionID)
+	t, err := impl.TenantStorer.GetByID(ctx, req.TenantID)
 	if err != nil {
-		impl.Logger.Error("in-memory set error", slog.Any("err", err))
+		impl.Logger.Error("database get by id error", slog.Any("err", err))
 		return err
 	}
-
-	var u *user_s.User
-	err = json.Unmarshal(uBin, &u)
-	if err != nil {
-		impl.Logger.Error("unmarshal error", slog.Any("err", err))
-		return err
+	if t == nil {
+		return httperror.NewForBadRequestWithSingleField("tenant_id", "does not exist")
 	}
 
 	////
 	//// Set the user's logged in session to point to specific tenant.
 	////
 
-	// Set the tenant id of the user's authenticated session.
-	u.TenantID = req.TenantID
-
-	// Set expiry duration.
-	expiry := 14 * 24 * time.Hour
-
-	// Start our session using an access and refresh token.
-	newSessionUUID := impl.UUID.NewUUID()
-
-	// Save the session.
-	if err := impl.Cache.SetWithExpiry(ctx, newSessionUUID, uBin, expiry); err != nil {
-		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+	if err := impl.Session.VisitTenant(ctx, sessionID, t.ID, t.Name); err != nil {
+		impl.Logger.Error("session visit tenant error", slog.Any("err", err))
 		return err
 	}
-
 	return nil
 }
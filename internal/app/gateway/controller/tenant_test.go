@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/cache/mongodbcache"
+	session_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/controller"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+)
+
+// fakeCache keeps the cached values in memory.
+type fakeCache struct {
+	mongodbcache.Cacher
+	values map[string][]byte
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.values[key], nil
+}
+
+// fakeSessionController keeps the sessions in the cache.
+type fakeSessionController struct {
+	session_c.SessionController
+	cache *fakeCache
+}
+
+func (s *fakeSessionController) Create(ctx context.Context, u *user_s.User, expiry time.Duration) (string, error) {
+	uBin, err := json.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+	sessionID := primitive.NewObjectID().Hex()
+	s.cache.values[sessionID] = uBin
+	return sessionID, nil
+}
+
+func (s *fakeSessionController) VisitTenant(ctx context.Context, sessionID string, tenantID primitive.ObjectID, tenantName string) error {
+	var u user_s.User
+	if err := json.Unmarshal(s.cache.values[sessionID], &u); err != nil {
+		return err
+	}
+	u.TenantID = tenantID
+	u.TenantName = tenantName
+	uBin, err := json.Marshal(&u)
+	if err != nil {
+		return err
+	}
+	s.cache.values[sessionID] = uBin
+	return nil
+}
+
+// fakeTenantStorer returns a single tenant.
+type fakeTenantStorer struct {
+	tenant_s.TenantStorer
+	tenant *tenant_s.Tenant
+}
+
+func (s *fakeTenantStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*tenant_s.Tenant, error) {
+	if s.tenant != nil && s.tenant.ID == id {
+		return s.tenant, nil
+	}
+	return nil, nil
+}
+
+func TestExecutiveVisitsTenantSwitchesSessionTenant(t *testing.T) {
+	visited := &tenant_s.Tenant{ID: primitive.NewObjectID(), Name: "Visited"}
+	cache := &fakeCache{values: map[string][]byte{}}
+	c := &GatewayControllerImpl{
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Cache:        cache,
+		TenantStorer: &fakeTenantStorer{tenant: visited},
+		Session:      &fakeSessionController{cache: cache},
+	}
+
+	u := &user_s.User{ID: primitive.NewObjectID(), Role: user_s.UserRoleExecutive, TenantID: primitive.NewObjectID(), OTPValidated: true}
+	sessionID, _ := c.Session.Create(context.Background(), u, time.Hour)
+	ctx := context.WithValue(context.Background(), constants.SessionID, sessionID)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, u.Role)
+
+	if err := c.ExecutiveVisitsTenant(ctx, &ExecutiveVisitsTenantRequest{TenantID: visited.ID}); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	var cached user_s.User
+	if err := json.Unmarshal(cache.values[sessionID], &cached); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if cached.TenantID != visited.ID || cached.TenantName != visited.Name {
+		t.Errorf("expected the session of the visited tenant but received %v", cached.TenantID)
+	}
+	if !cached.OTPValidated {
+		t.Errorf("expected the 2FA validation of the session to be kept")
+	}
+
+	ctx = context.WithValue(ctx, constants.SessionUserRole, int8(user_s.UserRoleFrontlineStaff))
+	if err := c.ExecutiveVisitsTenant(ctx, &ExecutiveVisitsTenantRequest{TenantID: visited.ID}); err == nil {
+		t.Errorf("expected non-executives to be refused")
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	usertoken_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/controller"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// VerifyEmail function marks the email address of the user as verified with
+// the code emailed to them.
+func (impl *GatewayControllerImpl) VerifyEmail(ctx context.Context, code string) error {
+	// Use up the code, else return a `400 Bad Request` error.
+	t, err := impl.UserToken.Consume(ctx, usertoken_s.PurposeEmailVerification, code)
+	if err != nil {
+		return err
+	}
+
+	// Lookup the user in our database, else return a `400 Bad Request` error.
+	u, err := impl.UserStorer.GetByID(ctx, t.UserID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return err
+	}
+	// The code only verifies the email address it was emailed to.
+	if u == nil || u.Email != t.Email {
+		impl.Logger.Warn("user does not exist or changed email validation error", slog.Any("user_id", t.UserID))
+		return httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
+	}
+
+	u.WasEmailVerified = true
+	u.ModifiedAt = time.Now()
+	if err := impl.UserStorer.UpdateByID(ctx, u); err != nil {
+		impl.Logger.Error("update error", slog.Any("err", err))
+		return err
+	}
+	if err := impl.Session.SyncByUserID(ctx, u.ID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// ResendVerificationEmail function emails a new verification code to the
+// authenticated user. Codes emailed earlier stop working.
+func (impl *GatewayControllerImpl) ResendVerificationEmail(ctx context.Context) error {
+	// Extract from our session the following data.
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	// Lookup the user in our database, else return a `400 Bad Request` error.
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return err
+	}
+	if u == nil {
+		impl.Logger.Warn("user does not exist validation error")
+		return httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if u.WasEmailVerified {
+		return httperror.NewForBadRequestWithSingleField("email", "email address was already verified")
+	}
+
+	err = impl.UserToken.SendVerificationEmail(ctx, u)
+	if errors.Is(err, usertoken_c.ErrRateLimited) {
+		return httperror.NewForSingleField(http.StatusTooManyRequests, "email", "too many verification emails were sent, please try again later")
+	}
+	if err != nil {
+		impl.Logger.Error("failed sending verification email", slog.Any("user_id", u.ID), slog.Any("err", err))
+		return err
+	}
+	return nil
+}
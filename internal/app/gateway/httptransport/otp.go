@@ -129,3 +129,18 @@ func (h *Handler) DisableOTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (h *Handler) GenerateOTPRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.GenerateOTPRecoveryCodes(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
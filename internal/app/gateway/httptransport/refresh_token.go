@@ -8,6 +8,7 @@ import (
 	"time"
 
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 type RefreshTokenRequestIDO struct {
@@ -72,12 +73,12 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user, accessToken, accessTokenExpiryDate, refreshToken, refreshTokenExpiryDate, err := h.Controller.RefreshToken(ctx, requestData.Value)
-	if user == nil {
-		http.Error(w, "{'non_field_error':'user does not exist'}", http.StatusNotFound)
+	if err != nil {
+		httperror.ResponseError(w, err)
 		return
 	}
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusOK)
+	if user == nil {
+		http.Error(w, "{'non_field_error':'user does not exist'}", http.StatusNotFound)
 		return
 	}
 
@@ -0,0 +1,59 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+type VerifyEmailRequestIDO struct {
+	Code string `json:"code"`
+}
+
+func UnmarshalVerifyEmailRequest(ctx context.Context, r *http.Request) (*VerifyEmailRequestIDO, error) {
+	// Initialize our array which will store all the results from the remote server.
+	var requestData VerifyEmailRequestIDO
+
+	defer r.Body.Close()
+
+	// Read the JSON string and convert it into our golang stuct else we need
+	// to send a `400 Bad Request` errror message back to the client,
+	err := json.NewDecoder(r.Body).Decode(&requestData) // [1]
+	if err != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	// Perform our validation and return validation error on any issues detected.
+	if requestData.Code == "" {
+		return nil, httperror.NewForBadRequestWithSingleField("code", "missing value")
+	}
+	return &requestData, nil
+}
+
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data, err := UnmarshalVerifyEmailRequest(ctx, r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.VerifyEmail(ctx, data.Code); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := h.Controller.ResendVerificationEmail(ctx); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
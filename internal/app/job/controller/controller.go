@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	job_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
+)
+
+// ScheduledJob is a maintenance task which must run once per interval across
+// every node of the cluster.
+type ScheduledJob struct {
+	Name     string
+	Interval time.Duration
+
+	// Timeout is the maximum duration of a run. The job is locked for this
+	// duration so another node may take over if this node crashed.
+	Timeout time.Duration
+
+	// Run performs the task and returns a short summary of what was done.
+	Run func(ctx context.Context) (string, error)
+}
+
+// JobController Interface for scheduled job business logic controller.
+type JobController interface {
+	RunIfDue(ctx context.Context, j *ScheduledJob) (bool, error)
+	List(ctx context.Context) ([]*job_s.Job, error)
+}
+
+type JobControllerImpl struct {
+	Config    *config.Conf
+	Logger    *slog.Logger
+	JobStorer job_s.JobStorer
+
+	// NodeID identifies this running instance of the application as the
+	// owner of the job locks.
+	NodeID string
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	uuidp uuid.Provider,
+	job_storer job_s.JobStorer,
+) JobController {
+	hostname, _ := os.Hostname()
+	s := &JobControllerImpl{
+		Config:    appCfg,
+		Logger:    loggerp,
+		JobStorer: job_storer,
+		NodeID:    fmt.Sprintf("%s/%s", hostname, uuidp.NewUUID()),
+	}
+	s.Logger.Debug("job controller initialization started...")
+	s.Logger.Debug("job controller initialized", slog.String("node_id", s.NodeID))
+	return s
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	job_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// List function returns the status of every scheduled job which ran at least
+// once.
+func (impl *JobControllerImpl) List(ctx context.Context) ([]*job_s.Job, error) {
+	// Extract from our session the following data.
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
+
+	// Apply protection based on ownership and role.
+	if userRole != user_s.UserRoleExecutive {
+		impl.Logger.Error("authenticated user is not executive role error",
+			slog.Any("role", userRole),
+			slog.Any("userID", userID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you role does not grant you access to this")
+	}
+
+	jobs, err := impl.JobStorer.ListAll(ctx)
+	if err != nil {
+		impl.Logger.Error("database list all error", slog.Any("error", err))
+		return nil, err
+	}
+	for _, j := range jobs {
+		j.NextRunAt = j.LastStartedAt.Add(time.Duration(j.IntervalInSeconds) * time.Second)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	job_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
+)
+
+// RunIfDue function runs the job if its interval passed since its last run and
+// no other node is running it. Returns true if the job ran.
+func (impl *JobControllerImpl) RunIfDue(ctx context.Context, j *ScheduledJob) (bool, error) {
+	acquired, err := impl.JobStorer.AcquireLock(ctx, j.Name, impl.NodeID, j.Interval, j.Timeout)
+	if err != nil {
+		impl.Logger.Error("failed acquiring job lock",
+			slog.String("job", j.Name),
+			slog.Any("error", err))
+		return false, err
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	impl.Logger.Debug("job started", slog.String("job", j.Name))
+	result, runErr := impl.run(ctx, j)
+
+	run := &job_s.JobRun{
+		FinishedAt: time.Now(),
+		Status:     job_s.StatusSucceeded,
+		Result:     result,
+	}
+	if runErr != nil {
+		impl.Logger.Error("job failed",
+			slog.String("job", j.Name),
+			slog.Any("error", runErr))
+		run.Status = job_s.StatusFailed
+		run.Error = runErr.Error()
+	} else {
+		impl.Logger.Info("job finished",
+			slog.String("job", j.Name),
+			slog.String("result", result))
+	}
+
+	// Record the outcome even if the application is shutting down so the lock
+	// does not stay held until the lease ended.
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := impl.JobStorer.ReleaseLock(saveCtx, j.Name, impl.NodeID, run); err != nil {
+		impl.Logger.Error("failed releasing job lock",
+			slog.String("job", j.Name),
+			slog.Any("error", err))
+		return true, err
+	}
+	return true, runErr
+}
+
+// run function runs the job within its timeout. A panic is returned as an
+// error so a faulty job cannot bring the application down.
+func (impl *JobControllerImpl) run(ctx context.Context, j *ScheduledJob) (result string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, j.Timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return j.Run(ctx)
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	job_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
+)
+
+// fakeJobStorer grants the lock if `locked` is false and keeps the outcome of
+// the last run.
+type fakeJobStorer struct {
+	job_s.JobStorer
+	locked  bool
+	lastRun *job_s.JobRun
+}
+
+func (s *fakeJobStorer) AcquireLock(ctx context.Context, name string, owner string, interval time.Duration, lease time.Duration) (bool, error) {
+	if s.locked {
+		return false, nil
+	}
+	s.locked = true
+	return true, nil
+}
+
+func (s *fakeJobStorer) ReleaseLock(ctx context.Context, name string, owner string, run *job_s.JobRun) error {
+	s.locked = false
+	s.lastRun = run
+	return nil
+}
+
+func newRunTestController(storer *fakeJobStorer) *JobControllerImpl {
+	return &JobControllerImpl{
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		JobStorer: storer,
+		NodeID:    "test",
+	}
+}
+
+func TestRunIfDueSkipsLockedJob(t *testing.T) {
+	storer := &fakeJobStorer{locked: true}
+	c := newRunTestController(storer)
+
+	ran, err := c.RunIfDue(context.Background(), &ScheduledJob{
+		Name:    "test",
+		Timeout: time.Second,
+		Run: func(ctx context.Context) (string, error) {
+			t.Error("locked job must not run")
+			return "", nil
+		},
+	})
+	if ran || err != nil {
+		t.Errorf("expected job to be skipped but received %v, %v", ran, err)
+	}
+}
+
+func TestRunIfDueRecordsOutcome(t *testing.T) {
+	storer := &fakeJobStorer{}
+	c := newRunTestController(storer)
+
+	ran, err := c.RunIfDue(context.Background(), &ScheduledJob{
+		Name:    "test",
+		Timeout: time.Second,
+		Run: func(ctx context.Context) (string, error) {
+			return "done", nil
+		},
+	})
+	if !ran || err != nil {
+		t.Fatalf("expected job to run but received %v, %v", ran, err)
+	}
+	if storer.locked || storer.lastRun.Status != job_s.StatusSucceeded || storer.lastRun.Result != "done" {
+		t.Errorf("unexpected outcome %+v", storer.lastRun)
+	}
+
+	// A failing job releases the lock as well.
+	_, err = c.RunIfDue(context.Background(), &ScheduledJob{
+		Name:    "test",
+		Timeout: time.Second,
+		Run: func(ctx context.Context) (string, error) {
+			return "", errors.New("unreachable")
+		},
+	})
+	if err == nil || storer.locked || storer.lastRun.Status != job_s.StatusFailed {
+		t.Errorf("expected failure to be recorded but received %v, %+v", err, storer.lastRun)
+	}
+}
+
+func TestRunIfDueRecoversPanic(t *testing.T) {
+	storer := &fakeJobStorer{}
+	c := newRunTestController(storer)
+
+	_, err := c.RunIfDue(context.Background(), &ScheduledJob{
+		Name:    "test",
+		Timeout: time.Second,
+		Run: func(ctx context.Context) (string, error) {
+			panic("boom")
+		},
+	})
+	if err == nil || storer.lastRun.Status != job_s.StatusFailed {
+		t.Errorf("expected panic to be recorded as failure but received %v, %+v", err, storer.lastRun)
+	}
+}
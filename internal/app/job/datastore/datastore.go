@@ -0,0 +1,68 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+const (
+	StatusRunning   = 1
+	StatusSucceeded = 2
+	StatusFailed    = 3
+)
+
+// Job represents the state of a scheduled maintenance job shared by every
+// node of the cluster. The record doubles as the distributed lock of the job.
+type Job struct {
+	Name              string    `bson:"_id" json:"name"`
+	IntervalInSeconds int64     `bson:"interval_in_seconds" json:"interval_in_seconds"`
+	Status            int8      `bson:"status" json:"status"`
+	LockedBy          string    `bson:"locked_by" json:"locked_by"`
+	LockedUntil       time.Time `bson:"locked_until" json:"locked_until"`
+	LastStartedAt     time.Time `bson:"last_started_at" json:"last_started_at"`
+	LastFinishedAt    time.Time `bson:"last_finished_at" json:"last_finished_at"`
+	LastSucceededAt   time.Time `bson:"last_succeeded_at" json:"last_succeeded_at"`
+	LastResult        string    `bson:"last_result" json:"last_result"`
+	LastError         string    `bson:"last_error" json:"last_error"`
+	RunCount          uint64    `bson:"run_count" json:"run_count"`
+	FailureCount      uint64    `bson:"failure_count" json:"failure_count"`
+	NextRunAt         time.Time `bson:"-" json:"next_run_at"`
+}
+
+// JobRun represents the outcome of a single run of a job.
+type JobRun struct {
+	FinishedAt time.Time
+	Status     int8
+	Result     string
+	Error      string
+}
+
+// JobStorer Interface for job.
+type JobStorer interface {
+	AcquireLock(ctx context.Context, name string, owner string, interval time.Duration, lease time.Duration) (bool, error)
+	ReleaseLock(ctx context.Context, name string, owner string, run *JobRun) error
+	ListAll(ctx context.Context) ([]*Job, error)
+}
+
+type JobStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) JobStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("jobs")
+
+	s := &JobStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AcquireLock function locks the job for the owner until the lease ended.
+// Returns false if another node holds the lock or if the job already started
+// within the interval, in which case the job must not run.
+//
+// DEVELOPERS NOTE:
+// The record is upserted so the first node to run a new job creates it. When
+// the filter does not match an existing record the upsert collides on the
+// `_id` which tells us the job is not ours to run.
+func (impl JobStorerImpl) AcquireLock(ctx context.Context, name string, owner string, interval time.Duration, lease time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":             name,
+		"locked_until":    bson.M{"$not": bson.M{"$gt": now}},
+		"last_started_at": bson.M{"$not": bson.M{"$gt": now.Add(-interval)}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"interval_in_seconds": int64(interval.Seconds()),
+			"status":              StatusRunning,
+			"locked_by":           owner,
+			"locked_until":        now.Add(lease),
+			"last_started_at":     now,
+		},
+	}
+
+	res, err := impl.Collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0 || res.UpsertedCount > 0, nil
+}
+
+// ReleaseLock function records the outcome of the run and unlocks the job if
+// the owner still holds the lock.
+func (impl JobStorerImpl) ReleaseLock(ctx context.Context, name string, owner string, run *JobRun) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	set := bson.M{
+		"status":           run.Status,
+		"locked_by":        "",
+		"locked_until":     time.Time{},
+		"last_finished_at": run.FinishedAt,
+		"last_result":      run.Result,
+		"last_error":       run.Error,
+	}
+	inc := bson.M{"run_count": 1}
+	if run.Status == StatusSucceeded {
+		set["last_succeeded_at"] = run.FinishedAt
+	} else {
+		inc["failure_count"] = 1
+	}
+
+	filter := bson.M{"_id": name, "locked_by": owner}
+	_, err := impl.Collection.UpdateOne(ctx, filter, bson.M{"$set": set, "$inc": inc})
+	return err
+}
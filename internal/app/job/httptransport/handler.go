@@ -0,0 +1,21 @@
+package httptransport
+
+import (
+	"log/slog"
+
+	job_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/controller"
+)
+
+// Handler Creates http request handler
+type Handler struct {
+	Logger     *slog.Logger
+	Controller job_c.JobController
+}
+
+// NewHandler Constructor
+func NewHandler(loggerp *slog.Logger, c job_c.JobController) *Handler {
+	return &Handler{
+		Logger:     loggerp,
+		Controller: c,
+	}
+}
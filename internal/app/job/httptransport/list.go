@@ -0,0 +1,28 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	job_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	m, err := h.Controller.List(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func MarshalListResponse(res []*job_s.Job, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+)
+
+// ArchiveManifestFilename is the name of the file inside every archive which
+// describes the contents of the archive.
+const ArchiveManifestFilename = "manifest.json"
+
+// ArchiveManifestEntry describes a single object file inside the archive.
+type ArchiveManifestEntry struct {
+	ObjectFileID   string `json:"object_file_id"`
+	Path           string `json:"path"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Filename       string `json:"filename"`
+	Classification uint64 `json:"classification"`
+	Size           int64  `json:"size"`
+	SHA256         string `json:"sha256"`
+}
+
+// ArchiveManifest describes the contents of the archive.
+type ArchiveManifest struct {
+	SmartFolderID   string                  `json:"smart_folder_id"`
+	SmartFolderName string                  `json:"smart_folder_name"`
+	GeneratedAt     time.Time               `json:"generated_at"`
+	Files           []*ArchiveManifestEntry `json:"files"`
+}
+
+// Archive streams a ZIP archive of object files. The archive is written
+// one object at a time so the contents are never loaded fully into memory.
+type Archive struct {
+	Filename    string
+	ObjectFiles []*objectfile_s.ObjectFile
+	Manifest    *ArchiveManifest
+
+	storage object_storage.ObjectStorager
+	logger  *slog.Logger
+}
+
+// NewArchive constructor that returns an archive for the object files of the
+// smart folder. Archived object files and files whose upload did not finish
+// are excluded.
+func NewArchive(storage object_storage.ObjectStorager, logger *slog.Logger, smartFolderID string, smartFolderName string, ofs []*objectfile_s.ObjectFile) *Archive {
+	files := make([]*objectfile_s.ObjectFile, 0, len(ofs))
+	for _, of := range ofs {
+		if of.Status == objectfile_s.StatusActive {
+			files = append(files, of)
+		}
+	}
+	return &Archive{
+		Filename:    fmt.Sprintf("%s.zip", sanitizeArchiveName(smartFolderName, "smart-folder")),
+		ObjectFiles: files,
+		Manifest: &ArchiveManifest{
+			SmartFolderID:   smartFolderID,
+			SmartFolderName: smartFolderName,
+			GeneratedAt:     time.Now(),
+			Files:           []*ArchiveManifestEntry{},
+		},
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// Write function streams the ZIP archive into the writer. The manifest is
+// written last so it can include the checksums computed while streaming.
+func (a *Archive) Write(ctx context.Context, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	usedPaths := map[string]bool{ArchiveManifestFilename: true}
+	for _, of := range a.ObjectFiles {
+		entryPath := uniqueArchivePath(usedPaths, archiveFilename(of))
+
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     entryPath,
+			Method:   zip.Deflate,
+			Modified: of.ModifiedAt,
+		})
+		if err != nil {
+			a.logger.Error("failed creating archive entry", slog.Any("error", err))
+			return err
+		}
+
+		reader, err := a.storage.GetBinaryData(ctx, of.ObjectKey)
+		if err != nil {
+			a.logger.Error("failed getting binary data",
+				slog.Any("object_file_id", of.ID),
+				slog.Any("error", err))
+			return err
+		}
+
+		hasher := sha256.New()
+		size, err := io.Copy(io.MultiWriter(fw, hasher), reader)
+		reader.Close()
+		if err != nil {
+			a.logger.Error("failed streaming object into archive",
+				slog.Any("object_file_id", of.ID),
+				slog.Any("error", err))
+			return err
+		}
+
+		a.Manifest.Files = append(a.Manifest.Files, &ArchiveManifestEntry{
+			ObjectFileID:   of.ID.Hex(),
+			Path:           entryPath,
+			Name:           of.Name,
+			Description:    of.Description,
+			Filename:       of.Filename,
+			Classification: of.Classification,
+			Size:           size,
+			SHA256:         hex.EncodeToString(hasher.Sum(nil)),
+		})
+	}
+
+	mw, err := zw.Create(ArchiveManifestFilename)
+	if err != nil {
+		a.logger.Error("failed creating archive manifest", slog.Any("error", err))
+		return err
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a.Manifest); err != nil {
+		a.logger.Error("failed writing archive manifest", slog.Any("error", err))
+		return err
+	}
+
+	return zw.Close()
+}
+
+// archiveFilename returns the name the object file will have in the archive.
+func archiveFilename(of *objectfile_s.ObjectFile) string {
+	filename := of.Filename
+	if filename == "" {
+		filename = filepath.Base(of.ObjectKey)
+	}
+	return sanitizeArchiveName(filename, of.ID.Hex())
+}
+
+// sanitizeArchiveName removes path separators so entries cannot escape the
+// archive root when extracted.
+func sanitizeArchiveName(name string, fallback string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(strings.TrimSpace(name))
+	if name == "" || name == "." || name == ".." {
+		return fallback
+	}
+	return name
+}
+
+// uniqueArchivePath ensures duplicate filenames do not overwrite each other
+// by appending a counter, ex: `report.pdf` becomes `report (2).pdf`.
+func uniqueArchivePath(used map[string]bool, name string) string {
+	candidate := name
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+	used[candidate] = true
+	return candidate
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"io"
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -9,9 +10,12 @@ import (
 
 	mg "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/emailer/mailgun"
 	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
 	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
 	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	uploadsession_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
@@ -22,23 +26,44 @@ type ObjectFileController interface {
 	Create(ctx context.Context, req *ObjectFileCreateRequestIDO) (*domain.ObjectFile, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error)
 	GetPresignedURLByID(ctx context.Context, id primitive.ObjectID) (*PresignedURLResponseIDO, error)
-	GetContent(ctx context.Context, id primitive.ObjectID) ([]byte, string, string, error)
+	GetContent(ctx context.Context, id primitive.ObjectID) (*ObjectFileContentResponseIDO, error)
 	UpdateByID(ctx context.Context, ns *ObjectFileUpdateRequestIDO) (*domain.ObjectFile, error)
 	ListByFilter(ctx context.Context, f *domain.ObjectFileListFilter) (*domain.ObjectFileListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *domain.ObjectFileListFilter) ([]*domain.ObjectFileAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	ListVersionsByID(ctx context.Context, id primitive.ObjectID) ([]*domain.ObjectFileVersion, error)
+	GetVersionContentByID(ctx context.Context, id primitive.ObjectID, number int) (*ObjectFileContentResponseIDO, error)
+	RestoreVersionByID(ctx context.Context, id primitive.ObjectID, number int) (*domain.ObjectFile, error)
+	MigrateObjectKeys(ctx context.Context, cursor primitive.ObjectID) (*ObjectKeyMigrationResponseIDO, error)
+	ListTrashByFilter(ctx context.Context, f *domain.ObjectFileListFilter) (*domain.ObjectFileListResult, error)
+	RestoreByID(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error)
+	PurgeByID(ctx context.Context, id primitive.ObjectID) error
+	PurgeExpiredTrash(ctx context.Context) (int, error)
+	RecoverStalledUploads(ctx context.Context) (int, int, error)
+	RetryUploadByID(ctx context.Context, req *ObjectFileRetryUploadRequestIDO) (*domain.ObjectFile, error)
+	ReconcileStorage(ctx context.Context, req *StorageReconciliationRequestIDO) (*StorageReconciliationResponseIDO, error)
+	ReconcileAllStorage(ctx context.Context) (int, int, error)
+	InitiateUploadSession(ctx context.Context, req *UploadSessionInitiateRequestIDO) (*UploadSessionResponseIDO, error)
+	GetUploadSessionByID(ctx context.Context, id primitive.ObjectID) (*UploadSessionResponseIDO, error)
+	UploadChunk(ctx context.Context, id primitive.ObjectID, number int32, content io.Reader) (*UploadSessionResponseIDO, error)
+	CompleteUploadSession(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error)
+	AbortUploadSession(ctx context.Context, id primitive.ObjectID) error
+	CleanupAbandonedUploadSessions(ctx context.Context) (int, error)
 }
 
 type ObjectFileControllerImpl struct {
-	Config            *config.Conf
-	Logger            *slog.Logger
-	UUID              uuid.Provider
-	ObjectStorage     object_storage.ObjectStorager
-	Emailer           mg.Emailer
-	DbClient          *mongo.Client
-	SmartFolderStorer smartfolder_s.SmartFolderStorer
-	ObjectFileStorer  objectfile_s.ObjectFileStorer
-	UserStorer        user_s.UserStorer
+	Config              *config.Conf
+	Logger              *slog.Logger
+	UUID                uuid.Provider
+	ObjectStorage       object_storage.ObjectStorager
+	Emailer             mg.Emailer
+	DbClient            *mongo.Client
+	SmartFolderStorer   smartfolder_s.SmartFolderStorer
+	ObjectFileStorer    objectfile_s.ObjectFileStorer
+	UserStorer          user_s.UserStorer
+	TenantStorer        tenant_s.TenantStorer
+	UploadSessionStorer uploadsession_s.UploadSessionStorer
+	AuditEvent          auditevent_c.AuditEventController
 }
 
 func NewController(
@@ -51,17 +76,23 @@ func NewController(
 	smartfolder_s smartfolder_s.SmartFolderStorer,
 	org_storer objectfile_s.ObjectFileStorer,
 	usr_storer user_s.UserStorer,
+	tenant_storer tenant_s.TenantStorer,
+	us_storer uploadsession_s.UploadSessionStorer,
+	ae_controller auditevent_c.AuditEventController,
 ) ObjectFileController {
 	s := &ObjectFileControllerImpl{
-		Config:            appCfg,
-		Logger:            loggerp,
-		UUID:              uuidp,
-		ObjectStorage:     object,
-		Emailer:           emailer,
-		DbClient:          client,
-		SmartFolderStorer: smartfolder_s,
-		ObjectFileStorer:  org_storer,
-		UserStorer:        usr_storer,
+		Config:              appCfg,
+		Logger:              loggerp,
+		UUID:                uuidp,
+		ObjectStorage:       object,
+		Emailer:             emailer,
+		DbClient:            client,
+		SmartFolderStorer:   smartfolder_s,
+		ObjectFileStorer:    org_storer,
+		UserStorer:          usr_storer,
+		TenantStorer:        tenant_storer,
+		UploadSessionStorer: us_storer,
+		AuditEvent:          ae_controller,
 	}
 	s.Logger.Debug("objectfile controller initialization started...")
 	s.Logger.Debug("objectfile controller initialized")
@@ -2,16 +2,21 @@ package controller
 
 import (
 	"context"
-	"fmt"
-	"mime/multipart"
+	"errors"
+	"io"
+	"net/http"
 	"time"
 
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	a_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
@@ -20,9 +25,10 @@ type ObjectFileCreateRequestIDO struct {
 	Description    string // Optional
 	FileName       string
 	FileType       string
-	File           multipart.File
+	File           io.Reader // Streamed into the object storage, it is read only once.
 	SmartFolderID  primitive.ObjectID
 	Classification uint64
+	AllowDuplicate bool // Optional. If true, a file with the same content as an existing file is accepted but flagged.
 }
 
 func validateCreateRequest(dirtyData *ObjectFileCreateRequestIDO) error {
@@ -46,9 +52,6 @@ func validateCreateRequest(dirtyData *ObjectFileCreateRequestIDO) error {
 func (c *ObjectFileControllerImpl) Create(ctx context.Context, req *ObjectFileCreateRequestIDO) (*a_d.ObjectFile, error) {
 	// Extract from our session the following data.
 	orgID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
-	orgName := ctx.Value(constants.SessionUserTenantName).(string)
-	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
-	userName := ctx.Value(constants.SessionUserName).(string)
 
 	if err := validateCreateRequest(req); err != nil {
 		c.Logger.Warn("failed validation",
@@ -58,14 +61,19 @@ func (c *ObjectFileControllerImpl) Create(ctx context.Context, req *ObjectFileCr
 		return nil, err
 	}
 
-	sf, err := c.SmartFolderStorer.GetByID(ctx, req.SmartFolderID)
+	sf, err := c.getUploadableSmartFolder(ctx, req.SmartFolderID)
 	if err != nil {
-		c.Logger.Error("failed getting smart folder", slog.Any("error", err))
+		return nil, err
+	}
+
+	// Count the file against the storage quota of the tenant before it is
+	// uploaded; its size is only known once it was streamed.
+	if err := c.reserveUsage(ctx, orgID, 0, 1); err != nil {
 		return nil, err
 	}
 
 	// Generate the key of our upload.
-	objectKey := fmt.Sprintf("ten_%v/cat_%d/subcat_%d/class_%d/%v", orgID.Hex(), sf.Category, sf.SubCategory, req.Classification, req.FileName)
+	objectKey := c.generateObjectKey(orgID, sf.Category, sf.SubCategory, req.Classification)
 
 	// For debugging purposes only.
 	c.Logger.Debug("pre-upload meta",
@@ -78,19 +86,109 @@ func (c *ObjectFileControllerImpl) Create(ctx context.Context, req *ObjectFileCr
 		slog.Any("classification", req.Classification),
 	)
 
-	go func(file multipart.File, objkey string) {
-		c.Logger.Debug("beginning private object file upload...")
-		if err := c.ObjectStorage.UploadContentFromMulipart(context.Background(), objkey, file); err != nil {
-			c.Logger.Error("private object file upload error", slog.Any("error", err))
-			// Do not return an error, simply continue this function as there might
-			// be a case were the file was removed on the object bucket by ourselves
-			// or some other reason.
+	// Create our meta record in the database before uploading so a failed
+	// or interrupted upload is never hidden behind an active record.
+	res := newPendingObjectFile(ctx, req, sf, objectKey)
+
+	if err := c.ObjectFileStorer.Create(ctx, res); err != nil {
+		c.Logger.Error("objectfile create error", slog.Any("error", err))
+		c.releaseUsage(ctx, orgID, 0, 1)
+		return nil, err
+	}
+
+	// Stream the file into the object storage and wait for the upload to
+	// finish.
+	c.Logger.Debug("beginning private object file upload...")
+	info, uploadErr := c.uploadStream(ctx, objectKey, req.File, req.FileName)
+	var httpErr httperror.HTTPError
+	if errors.As(uploadErr, &httpErr) {
+		// The upload was rejected, not failed, so the upload never existed
+		// as far as staff are concerned.
+		c.discardRecord(ctx, res)
+		return nil, uploadErr
+	}
+	if uploadErr == nil {
+		// Reject the upload if its content exceeds the quota or duplicates
+		// another file.
+		dup, err := c.acceptUpload(ctx, orgID, primitive.NilObjectID, info, req.AllowDuplicate)
+		if err != nil {
+			c.discardUpload(ctx, objectKey)
+			c.discardRecord(ctx, res)
+			return nil, err
+		}
+		if dup != nil {
+			res.DuplicateOfObjectFileID = dup.ID
 		}
+	}
+
+	res.UploadAttemptCount = 1
+	res.ModifiedAt = time.Now()
+	if uploadErr != nil {
+		c.Logger.Error("private object file upload error",
+			slog.Any("object_file_id", res.ID),
+			slog.Any("error", uploadErr))
+		res.Status = a_d.StatusError
+		res.UploadError = uploadErr.Error()
+	} else {
 		c.Logger.Debug("Finished private object file upload")
-	}(req.File, objectKey)
+		activateObjectFile(res, info)
+	}
+
+	// Save the real state of the upload. The request may have been cancelled
+	// during the upload so do not let the cancellation skip this save.
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := c.ObjectFileStorer.UpdateByID(saveCtx, res); err != nil {
+		c.Logger.Error("database update by id error", slog.Any("error", err))
+		return nil, err
+	}
+
+	if uploadErr != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadGateway, "file", "failed uploading file, please try again")
+	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionCreate,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   res.ID,
+		TargetName: res.Name,
+		After:      res,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return res, nil
+}
+
+// getUploadableSmartFolder function returns the smart folder if the
+// authenticated user is granted the upload permission on it.
+func (c *ObjectFileControllerImpl) getUploadableSmartFolder(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error) {
+	if err := policy.AuthorizeVerifiedEmailForTenant(ctx, c.TenantStorer, c.Logger); err != nil {
+		return nil, err
+	}
+	sf, err := c.SmartFolderStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("failed getting smart folder", slog.Any("error", err))
+		return nil, err
+	}
+	if sf == nil || sf.IsTrashed() {
+		return nil, httperror.NewForBadRequestWithSingleField("smart_folder_id", "does not exist")
+	}
+	if err := policy.AuthorizeRecord(ctx, sf.TenantID, sf.AccessControlList, policy.PermissionUpload); err != nil {
+		c.Logger.Warn("smart folder access denied", slog.Any("smart_folder_id", sf.ID))
+		return nil, err
+	}
+	return sf, nil
+}
+
+// newPendingObjectFile function returns the record of an object file created
+// by the authenticated user whose content is not uploaded yet.
+func newPendingObjectFile(ctx context.Context, req *ObjectFileCreateRequestIDO, sf *smartfolder_s.SmartFolder, objectKey string) *a_d.ObjectFile {
+	// Extract from our session the following data.
+	orgID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	orgName := ctx.Value(constants.SessionUserTenantName).(string)
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName := ctx.Value(constants.SessionUserName).(string)
 
-	// Create our meta record in the database.
-	res := &a_d.ObjectFile{
+	return &a_d.ObjectFile{
 		TenantID:               orgID,
 		TenantName:             orgName,
 		ID:                     primitive.NewObjectID(),
@@ -103,19 +201,40 @@ func (c *ObjectFileControllerImpl) Create(ctx context.Context, req *ObjectFileCr
 		Name:                   req.Name,
 		Description:            req.Description,
 		Filename:               req.FileName,
+		FileType:               req.FileType,
 		ObjectKey:              objectKey,
+		ObjectKeyLayout:        a_d.ObjectKeyLayoutUnique,
 		ObjectURL:              "",
-		Status:                 a_d.StatusActive,
+		Status:                 a_d.StatusPending,
 		SmartFolderID:          sf.ID,
 		SmartFolderName:        sf.Name,
 		SmartFolderCategory:    sf.Category,
 		SmartFolderSubCategory: sf.SubCategory,
 		Classification:         req.Classification,
 	}
+}
 
-	if err := c.ObjectFileStorer.Create(ctx, res); err != nil {
-		c.Logger.Error("objectfile create error", slog.Any("error", err))
-		return nil, err
+// activateObjectFile function marks the pending object file as uploaded with
+// the content as its first version.
+func activateObjectFile(of *a_d.ObjectFile, info *fileInspection) {
+	of.MimeType = info.MimeType
+	of.Size = info.Size
+	of.SHA256 = info.SHA256
+	of.ContentType = contentTypeFromMimeType(info.MimeType)
+	of.Status = a_d.StatusActive
+	of.UploadedAt = time.Now()
+	of.CurrentVersion = 1
+	of.Versions = []*a_d.ObjectFileVersion{
+		{
+			Number:             1,
+			ObjectKey:          of.ObjectKey,
+			Filename:           of.Filename,
+			MimeType:           info.MimeType,
+			Size:               info.Size,
+			SHA256:             info.SHA256,
+			UploadedByUserID:   of.CreatedByUserID,
+			UploadedByUserName: of.CreatedByUserName,
+			UploadedAt:         of.UploadedAt,
+		},
 	}
-	return res, nil
 }
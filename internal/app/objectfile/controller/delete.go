@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
+// DeleteByID function moves the object file to the trash of the tenant. The
+// content is kept in the object storage until the object file is purged.
 func (impl *ObjectFileControllerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
 	// Extract from our session the following data.
-	tenantID, _ := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName, _ := ctx.Value(constants.SessionUserName).(string)
 
 	// Update the database.
 	objectFile, err := impl.GetByID(ctx, id)
@@ -25,25 +32,33 @@ func (impl *ObjectFileControllerImpl) DeleteByID(ctx context.Context, id primiti
 		impl.Logger.Error("database returns nothing from get by id")
 		return httperror.NewForBadRequestWithSingleField("message", fmt.Sprintf("object file does not exist for id: %s", id.Hex()))
 	}
-	if tenantID != objectFile.TenantID {
-		impl.Logger.Error("forbidden")
-		return httperror.NewForForbiddenWithSingleField("message", "you do not belong to this tenant")
+	if err := impl.authorizeObjectFile(ctx, objectFile, policy.PermissionDelete); err != nil {
+		return err
 	}
 
-	// Proceed to delete the physical files from AWS object.
-	if err := impl.ObjectStorage.DeleteByKeys(ctx, []string{objectFile.ObjectKey}); err != nil {
-		impl.Logger.Warn("object delete by keys error", slog.Any("error", err))
-		// Do not return an error, simply continue this function as there might
-		// be a case were the file was removed on the object bucket by ourselves
-		// or some other reason.
-	}
-	impl.Logger.Debug("deleted from remote object storage", slog.String("object_file_id", id.Hex()))
+	before := auditevent_c.Snapshot(objectFile)
+	objectFile.DeletedAt = time.Now()
+	objectFile.DeletedByUserID = userID
+	objectFile.DeletedByUserName = userName
+	objectFile.PurgeAt = objectFile.DeletedAt.Add(impl.trashRetention())
+	objectFile.DeletedWithSmartFolder = false
 
-	if err := impl.ObjectFileStorer.DeleteByID(ctx, objectFile.ID); err != nil {
-		impl.Logger.Error("database delete by id error", slog.Any("error", err))
+	if err := impl.ObjectFileStorer.UpdateByID(ctx, objectFile); err != nil {
+		impl.Logger.Error("database update by id error", slog.Any("error", err))
 		return err
 	}
-	impl.Logger.Debug("deleted from database", slog.String("object_file_id", id.Hex()))
+	impl.Logger.Debug("moved to trash", slog.String("object_file_id", id.Hex()))
+
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionDelete,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   objectFile.ID,
+		TargetName: objectFile.Name,
+		Before:     before,
+		After:      objectFile,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return nil
 }
@@ -2,13 +2,15 @@ package controller
 
 import (
 	"context"
-	"io/ioutil"
+	"io"
 	"log/slog"
 	"mime"
 	"path/filepath"
 	"time"
 
 	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -33,6 +35,14 @@ func (c *ObjectFileControllerImpl) GetByID(ctx context.Context, id primitive.Obj
 			slog.Any("error", err))
 		return nil, err
 	}
+	if m != nil {
+		if err := c.authorizeObjectFile(ctx, m, policy.PermissionView); err != nil {
+			return nil, err
+		}
+		if err := requireNotTrashed(m); err != nil {
+			return nil, err
+		}
+	}
 
 	// // Generate the URL.
 	// fileURL, err := c.ObjectStorage.GetPresignedURL(ctx, m.ObjectKey, 5*time.Minute)
@@ -68,6 +78,18 @@ func (c *ObjectFileControllerImpl) GetPresignedURLByID(ctx context.Context, id p
 		c.Logger.Error("database get by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := c.authorizeObjectFile(ctx, m, policy.PermissionView); err != nil {
+		return nil, err
+	}
+	if err := requireNotTrashed(m); err != nil {
+		return nil, err
+	}
+	if err := requireUploaded(m); err != nil {
+		return nil, err
+	}
 
 	// Generate the URL.
 	fileURL, err := c.ObjectStorage.GetDownloadablePresignedURL(ctx, m.ObjectKey, 15*time.Minute)
@@ -84,35 +106,63 @@ func (c *ObjectFileControllerImpl) GetPresignedURLByID(ctx context.Context, id p
 	return &PresignedURLResponseIDO{PresignedURL: fileURL}, nil
 }
 
-func (c *ObjectFileControllerImpl) GetContent(ctx context.Context, id primitive.ObjectID) ([]byte, string, string, error) {
+type ObjectFileContentResponseIDO struct {
+	Content     io.ReadCloser
+	Filename    string
+	ContentType string
+}
+
+// GetContent function returns a stream of the current content of the object
+// file. The caller is responsible for closing the content.
+func (c *ObjectFileControllerImpl) GetContent(ctx context.Context, id primitive.ObjectID) (*ObjectFileContentResponseIDO, error) {
 	// Retrieve from our database the record for the specific id.
 	m, err := c.ObjectFileStorer.GetByID(ctx, id)
 	if err != nil {
 		c.Logger.Error("database get by id error", slog.Any("error", err))
-		return nil, "", "", err
+		return nil, err
+	}
+	if m == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := c.authorizeObjectFile(ctx, m, policy.PermissionView); err != nil {
+		return nil, err
+	}
+	if err := requireNotTrashed(m); err != nil {
+		return nil, err
+	}
+	if err := requireUploaded(m); err != nil {
+		return nil, err
 	}
 
-	// Generate the URL.
 	reader, err := c.ObjectStorage.GetBinaryData(ctx, m.ObjectKey)
 	if err != nil {
-		return nil, "", "", err
+		c.Logger.Error("object get binary data error",
+			slog.String("object_file_id", id.Hex()),
+			slog.Any("error", err))
+		return nil, err
 	}
-	defer reader.Close()
 
-	// Read the contents of the file.
-	content, err := ioutil.ReadAll(reader)
-	if err != nil {
-		return nil, "", "", err
+	// Determine the filename and content type from the detected MIME type of
+	// the upload. Records uploaded before the MIME type was detected fall back
+	// to the extension of the filename, and records which are missing the
+	// filename fall back to the object key as the legacy key layout contains
+	// the filename with its extension.
+	filename := m.Filename
+	if filename == "" {
+		filename = filepath.Base(m.ObjectKey)
+	}
+	contentType := m.MimeType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
 	}
-
-	// Determine the filename and content type from the object key.
-	// This assumes the object key contains the filename with its extension.
-	filename := filepath.Base(m.ObjectKey)
-	contentType := mime.TypeByExtension(filepath.Ext(filename))
 	if contentType == "" {
 		// Default content type if not found.
 		contentType = "application/octet-stream"
 	}
 
-	return content, filename, contentType, nil
+	return &ObjectFileContentResponseIDO{
+		Content:     reader,
+		Filename:    filename,
+		ContentType: contentType,
+	}, nil
 }
@@ -7,22 +7,25 @@ import (
 	"log/slog"
 
 	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
-	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
-	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (c *ObjectFileControllerImpl) ListByFilter(ctx context.Context, f *domain.ObjectFileListFilter) (*domain.ObjectFileListResult, error) {
 	// Extract from our session the following data.
-	orgID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
 	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
-	// Apply protection based on ownership and role.
-	if userRole != user_d.UserRoleExecutive {
-		f.TenantID = orgID // Force tenant tenancy restrictions.
+	// Apply protection based on ownership.
+	f.TenantID = policy.SessionTenantID(ctx) // Force tenant tenancy restrictions.
+	if err := policy.Authorize(ctx, policy.PermissionView); err != nil {
+		return nil, err
+	}
+	hidden, err := c.hiddenSmartFolderIDs(ctx)
+	if err != nil {
+		return nil, err
 	}
+	f.ExcludeSmartFolderIDs = hidden
 
 	c.Logger.Debug("fetching objectfiles now...", slog.Any("userID", userID))
 
@@ -34,6 +37,11 @@ func (c *ObjectFileControllerImpl) ListByFilter(ctx context.Context, f *domain.O
 	c.Logger.Debug("fetched objectfiles", slog.Any("aa", aa))
 
 	for _, a := range aa.Results {
+		// Only generate the URL for files whose content was uploaded.
+		if a.Status == domain.StatusPending || a.Status == domain.StatusError {
+			continue
+		}
+
 		// Generate the URL.
 		fileURL, err := c.ObjectStorage.GetPresignedURL(ctx, a.ObjectKey, 5*time.Minute)
 		if err != nil {
@@ -48,15 +56,17 @@ func (c *ObjectFileControllerImpl) ListByFilter(ctx context.Context, f *domain.O
 func (c *ObjectFileControllerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *domain.ObjectFileListFilter) ([]*domain.ObjectFileAsSelectOption, error) {
 	// Extract from our session the following data.
 	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 
 	// Apply protection based on ownership and role.
-	if userRole != user_d.UserRoleExecutive {
-		c.Logger.Error("authenticated user is not staff role error",
-			slog.Any("role", userRole),
-			slog.Any("userID", userID))
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you role does not grant you access to this")
+	f.TenantID = policy.SessionTenantID(ctx) // Force tenant tenancy restrictions.
+	if err := policy.Authorize(ctx, policy.PermissionView); err != nil {
+		return nil, err
+	}
+	hidden, err := c.hiddenSmartFolderIDs(ctx)
+	if err != nil {
+		return nil, err
 	}
+	f.ExcludeSmartFolderIDs = hidden
 
 	c.Logger.Debug("fetching objectfiles now...", slog.Any("userID", userID))
 
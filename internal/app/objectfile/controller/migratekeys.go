@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// migrateObjectKeysBatchSize is the number of object files migrated by a
+// single request so the migration never outlives the request.
+const migrateObjectKeysBatchSize = 100
+
+type ObjectKeyMigrationResponseIDO struct {
+	MigratedCount       int                  `json:"migrated_count"`
+	FailedCount         int                  `json:"failed_count"`
+	FailedObjectFileIDs []primitive.ObjectID `json:"failed_object_file_ids"`
+	NextCursor          primitive.ObjectID   `json:"next_cursor"`
+	HasNextPage         bool                 `json:"has_next_page"`
+}
+
+// MigrateObjectKeys function will copy the content of the next batch of object
+// files after the cursor using the legacy filename based key layout into
+// unique keys and update the records. The previous keys are removed once no
+// other record references them. Call it again with the returned cursor until
+// there is no next page; the operation is safe to run multiple times and
+// resumes where it was interrupted.
+func (c *ObjectFileControllerImpl) MigrateObjectKeys(ctx context.Context, cursor primitive.ObjectID) (*ObjectKeyMigrationResponseIDO, error) {
+	// Extract from our session the following data.
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
+
+	// Apply protection based on ownership and role.
+	if userRole != user_d.UserRoleExecutive {
+		c.Logger.Error("authenticated user is not staff role error",
+			slog.Any("role", userRole),
+			slog.Any("userID", userID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you role does not grant you access to this")
+	}
+
+	res := &ObjectKeyMigrationResponseIDO{
+		FailedObjectFileIDs: []primitive.ObjectID{},
+	}
+
+	// Fetch one more record then requested so we know if there is a next page.
+	ofs, err := c.ObjectFileStorer.ListByLegacyObjectKeyLayout(ctx, cursor, migrateObjectKeysBatchSize+1)
+	if err != nil {
+		c.Logger.Error("database list by legacy object key layout error", slog.Any("error", err))
+		return nil, err
+	}
+	if len(ofs) > migrateObjectKeysBatchSize {
+		res.HasNextPage = true
+		ofs = ofs[:migrateObjectKeysBatchSize]
+	}
+
+	for _, of := range ofs {
+		// Stop early if the client went away, the cursor lets it resume.
+		if ctx.Err() != nil {
+			res.HasNextPage = true
+			break
+		}
+		res.NextCursor = of.ID
+		if err := c.migrateObjectKey(ctx, of); err != nil {
+			c.Logger.Error("failed migrating object key",
+				slog.Any("object_file_id", of.ID),
+				slog.Any("error", err))
+			res.FailedCount++
+			res.FailedObjectFileIDs = append(res.FailedObjectFileIDs, of.ID)
+			continue
+		}
+		res.MigratedCount++
+	}
+
+	c.Logger.Info("migrated object keys",
+		slog.Int("migrated_count", res.MigratedCount),
+		slog.Int("failed_count", res.FailedCount),
+		slog.Any("next_cursor", res.NextCursor))
+	return res, nil
+}
+
+func (c *ObjectFileControllerImpl) migrateObjectKey(ctx context.Context, of *domain.ObjectFile) error {
+	// Make sure the current content is part of the history so it gets
+	// migrated with the rest of the versions.
+	ensureVersionHistory(of)
+
+	// STEP 1: Copy every referenced content into a new unique key.
+	oldKeys := of.AllObjectKeys()
+	newKeys := make(map[string]string, len(oldKeys))
+	for _, oldKey := range oldKeys {
+		newKey := c.generateObjectKey(of.TenantID, of.SmartFolderCategory, of.SmartFolderSubCategory, of.Classification)
+		if err := c.ObjectStorage.Copy(ctx, oldKey, newKey); err != nil {
+			return err
+		}
+		newKeys[oldKey] = newKey
+	}
+
+	// STEP 2: Point the record to the new keys.
+	if newKey, ok := newKeys[of.ObjectKey]; ok {
+		of.ObjectKey = newKey
+	}
+	for _, v := range of.Versions {
+		if newKey, ok := newKeys[v.ObjectKey]; ok {
+			v.ObjectKey = newKey
+		}
+	}
+	of.ObjectKeyLayout = domain.ObjectKeyLayoutUnique
+	of.ModifiedAt = time.Now()
+	if err := c.ObjectFileStorer.UpdateByID(ctx, of); err != nil {
+		return err
+	}
+
+	// STEP 3: Remove the previous keys which are no longer referenced. Keys
+	// shared by multiple records (because of previous collisions) are kept
+	// until the last record is migrated.
+	for _, oldKey := range oldKeys {
+		count, err := c.ObjectFileStorer.CountByObjectKey(ctx, oldKey)
+		if err != nil {
+			c.Logger.Warn("database count by object key error", slog.Any("error", err))
+			continue
+		}
+		if count > 0 {
+			continue
+		}
+		if err := c.ObjectStorage.DeleteByKeys(ctx, []string{oldKey}); err != nil {
+			c.Logger.Warn("object delete by keys error", slog.Any("error", err))
+			// Do not return an error, the record was already migrated.
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+)
+
+// authorizeObjectFile returns a `403 Forbidden` error if the authenticated
+// user is not granted the permission on the object file. Object files inherit
+// the access control list of their smart folder.
+func (c *ObjectFileControllerImpl) authorizeObjectFile(ctx context.Context, of *domain.ObjectFile, permission int8) error {
+	// Check the tenancy first so other tenants' smart folders are never read.
+	if err := policy.AuthorizeTenant(ctx, of.TenantID); err != nil {
+		c.Logger.Warn("objectfile does not belong to tenant", slog.String("object_file_id", of.ID.Hex()))
+		return err
+	}
+	sf, err := c.SmartFolderStorer.GetByID(ctx, of.SmartFolderID)
+	if err != nil {
+		c.Logger.Error("failed getting smart folder", slog.Any("error", err))
+		return err
+	}
+	var acl []*policy.AccessControlEntry
+	if sf != nil {
+		acl = sf.AccessControlList
+	}
+	if err := policy.AuthorizeRecord(ctx, of.TenantID, acl, permission); err != nil {
+		c.Logger.Warn("objectfile access denied",
+			slog.String("object_file_id", of.ID.Hex()),
+			slog.Any("permission", permission))
+		return err
+	}
+	return nil
+}
+
+// hiddenSmartFolderIDs returns the smart folders whose object files must not
+// be listed to the authenticated user.
+func (c *ObjectFileControllerImpl) hiddenSmartFolderIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	if !policy.IsRestrictedFromListing(ctx) {
+		return nil, nil
+	}
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	ids, err := c.SmartFolderStorer.ListIDsHiddenFromUser(ctx, policy.SessionTenantID(ctx), userID)
+	if err != nil {
+		c.Logger.Error("failed listing hidden smart folders", slog.Any("error", err))
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// reserveUsage function counts the bytes and files against the storage quota
+// of the tenant before they are uploaded and returns an error if the quota
+// would be exceeded.
+func (c *ObjectFileControllerImpl) reserveUsage(ctx context.Context, tenantID primitive.ObjectID, bytes int64, files int64) error {
+	t, err := c.TenantStorer.GetByID(ctx, tenantID)
+	if err != nil {
+		c.Logger.Error("failed getting tenant", slog.Any("error", err))
+		return err
+	}
+	if t == nil {
+		return httperror.NewForBadRequestWithSingleField("tenant_id", "does not exist")
+	}
+
+	ok, err := c.TenantStorer.ReserveUsage(ctx, t, bytes, files)
+	if err != nil {
+		c.Logger.Error("failed reserving tenant usage", slog.Any("error", err))
+		return err
+	}
+	if !ok {
+		c.Logger.Warn("tenant storage quota exceeded",
+			slog.Any("tenant_id", tenantID),
+			slog.Int64("bytes", bytes),
+			slog.Int64("files", files))
+		return httperror.NewForSingleField(http.StatusRequestEntityTooLarge, "file", fmt.Sprintf("uploading this file would exceed the storage quota of %s, please delete or purge some files first", t.Name))
+	}
+	return nil
+}
+
+// releaseUsage function removes the bytes and files from the usage of the
+// tenant. It is not cancelled with the request as the usage would otherwise
+// drift from the object files.
+func (c *ObjectFileControllerImpl) releaseUsage(ctx context.Context, tenantID primitive.ObjectID, bytes int64, files int64) {
+	if bytes == 0 && files == 0 {
+		return
+	}
+	if err := c.TenantStorer.AdjustUsage(context.WithoutCancel(ctx), tenantID, -bytes, -files); err != nil {
+		c.Logger.Warn("failed releasing tenant usage",
+			slog.Any("tenant_id", tenantID),
+			slog.Any("error", err))
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeTenantStorer grants or denies every reservation and keeps the usage
+// adjustments.
+type fakeTenantStorer struct {
+	tenant_s.TenantStorer
+	tenant        *tenant_s.Tenant
+	denied        bool
+	adjustedBytes int64
+	adjustedFiles int64
+}
+
+func (s *fakeTenantStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*tenant_s.Tenant, error) {
+	return s.tenant, nil
+}
+
+func (s *fakeTenantStorer) ListByFilter(ctx context.Context, f *tenant_s.TenantListFilter) (*tenant_s.TenantListResult, error) {
+	return &tenant_s.TenantListResult{Results: []*tenant_s.Tenant{s.tenant}}, nil
+}
+
+func (s *fakeTenantStorer) ReserveUsage(ctx context.Context, t *tenant_s.Tenant, bytes int64, files int64) (bool, error) {
+	return !s.denied, nil
+}
+
+func (s *fakeTenantStorer) AdjustUsage(ctx context.Context, id primitive.ObjectID, bytes int64, files int64) error {
+	s.adjustedBytes += bytes
+	s.adjustedFiles += files
+	return nil
+}
+
+// quotaTestObjectStorage accepts every deletion.
+type quotaTestObjectStorage struct {
+	object_storage.ObjectStorager
+}
+
+func (s *quotaTestObjectStorage) DeleteByKeys(ctx context.Context, keys []string) error {
+	return nil
+}
+
+func TestReserveUsageRejectsUploadOverQuota(t *testing.T) {
+	owner := primitive.NewObjectID()
+	c, _ := newTenancyTestController(t, nil)
+	c.TenantStorer = &fakeTenantStorer{
+		tenant: &tenant_s.Tenant{ID: owner, Name: "Acme", StorageQuotaInBytes: 100},
+		denied: true,
+	}
+
+	err := c.reserveUsage(context.Background(), owner, 200, 1)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected request entity too large error but received %v", err)
+	}
+}
+
+func TestPurgeReleasesUsage(t *testing.T) {
+	owner := primitive.NewObjectID()
+	of := &domain.ObjectFile{
+		ID:        primitive.NewObjectID(),
+		TenantID:  owner,
+		Status:    domain.StatusActive,
+		ObjectKey: "key-1",
+		Size:      10,
+		DeletedAt: time.Now(),
+		Versions: []*domain.ObjectFileVersion{
+			{Number: 1, ObjectKey: "key-1", Size: 10},
+			{Number: 2, ObjectKey: "key-2", Size: 30},
+			{Number: 3, ObjectKey: "key-1", Size: 10, RestoredFromVersion: 1},
+		},
+	}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+	c, _ := newTenancyTestController(t, of)
+	c.ObjectStorage = &quotaTestObjectStorage{}
+	c.AuditEvent = &fakeAuditEvent{}
+	tenants := &fakeTenantStorer{}
+	c.TenantStorer = tenants
+
+	if err := c.PurgeByID(ctx, of.ID); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	// The restored version reuses the content of the first version.
+	if tenants.adjustedBytes != -40 || tenants.adjustedFiles != -1 {
+		t.Errorf("expected -40 bytes and -1 file released but received %d bytes and %d files", tenants.adjustedBytes, tenants.adjustedFiles)
+	}
+}
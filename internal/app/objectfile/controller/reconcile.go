@@ -0,0 +1,315 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+const (
+	// reconcileBatchSize is the number of object files fetched at a time from
+	// the database during the reconciliation.
+	reconcileBatchSize = 100
+
+	// reconcileDeleteBatchSize is the maximum number of objects the object
+	// storage deletes in a single request.
+	reconcileDeleteBatchSize = 1000
+
+	// reconcileGracePeriod is how recent an upload may be to be left out of
+	// the reconciliation as its record or object may still be being saved.
+	reconcileGracePeriod = time.Hour
+
+	// missingObjectUploadError is saved on the object files whose content was
+	// found missing during a repair.
+	missingObjectUploadError = "content is missing from the object storage"
+)
+
+type StorageReconciliationRequestIDO struct {
+	// Repair marks the object files with missing content as failed uploads
+	// and deletes the orphaned objects; otherwise only a report is returned.
+	Repair bool `json:"repair"`
+}
+
+// MissingObjectIDO represents an object referenced by an object file which
+// does not exist in the object storage.
+type MissingObjectIDO struct {
+	ObjectFileID   primitive.ObjectID `json:"object_file_id"`
+	ObjectFileName string             `json:"object_file_name"`
+	ObjectKey      string             `json:"object_key"`
+	VersionNumber  int                `json:"version_number,omitempty"`
+	Size           int64              `json:"size"`
+	Status         int8               `json:"status"`
+}
+
+// OrphanedObjectIDO represents an object in the object storage which is not
+// referenced by any object file.
+type OrphanedObjectIDO struct {
+	ObjectKey    string    `json:"object_key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+type StorageReconciliationResponseIDO struct {
+	CheckedObjectFileCount int                  `json:"checked_object_file_count"`
+	CheckedObjectCount     int                  `json:"checked_object_count"`
+	MissingObjects         []*MissingObjectIDO  `json:"missing_objects"`
+	MissingSize            int64                `json:"missing_size"`
+	OrphanedObjects        []*OrphanedObjectIDO `json:"orphaned_objects"`
+	OrphanedSize           int64                `json:"orphaned_size"`
+	Repaired               bool                 `json:"repaired"`
+	MarkedAsErrorCount     int                  `json:"marked_as_error_count"`
+	DeletedObjectCount     int                  `json:"deleted_object_count"`
+}
+
+// ReconcileStorage function compares the object files of the tenant of the
+// authenticated user against the objects of the tenant in the object storage
+// and reports the object files with missing content and the objects which no
+// object file references, optionally repairing both.
+func (c *ObjectFileControllerImpl) ReconcileStorage(ctx context.Context, req *StorageReconciliationRequestIDO) (*StorageReconciliationResponseIDO, error) {
+	// Extract from our session the following data.
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userRole, _ := ctx.Value(constants.SessionUserRole).(int8)
+
+	// Apply protection based on ownership and role.
+	if userRole != user_d.UserRoleExecutive {
+		c.Logger.Error("authenticated user is not staff role error",
+			slog.Any("role", userRole),
+			slog.Any("userID", userID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you role does not grant you access to this")
+	}
+	return c.reconcileTenantStorage(ctx, policy.SessionTenantID(ctx), req.Repair)
+}
+
+// ReconcileAllStorage function reconciles the storage of every tenant without
+// repairing anything so the discrepancies get noticed in the logs. Returns the
+// number of missing and orphaned objects found.
+func (c *ObjectFileControllerImpl) ReconcileAllStorage(ctx context.Context) (int, int, error) {
+	missing, orphaned := 0, 0
+	f := &tenant_s.TenantListFilter{
+		PageSize:  reconcileBatchSize,
+		SortField: "_id",
+		SortOrder: 1,
+	}
+	for {
+		res, err := c.TenantStorer.ListByFilter(ctx, f)
+		if err != nil {
+			c.Logger.Error("database list by filter error", slog.Any("error", err))
+			return missing, orphaned, err
+		}
+		for _, t := range res.Results {
+			rr, err := c.reconcileTenantStorage(ctx, t.ID, false)
+			if err != nil {
+				return missing, orphaned, err
+			}
+			if len(rr.MissingObjects) > 0 || len(rr.OrphanedObjects) > 0 {
+				c.Logger.Warn("storage of tenant is out of sync",
+					slog.Any("tenant_id", t.ID),
+					slog.Int("missing_objects", len(rr.MissingObjects)),
+					slog.Int64("missing_size", rr.MissingSize),
+					slog.Int("orphaned_objects", len(rr.OrphanedObjects)),
+					slog.Int64("orphaned_size", rr.OrphanedSize))
+			}
+			missing += len(rr.MissingObjects)
+			orphaned += len(rr.OrphanedObjects)
+		}
+		if !res.HasNextPage {
+			break
+		}
+		f.Cursor = res.NextCursor
+	}
+	return missing, orphaned, nil
+}
+
+// reconcileTenantStorage function compares the object files of the tenant
+// against the objects of the tenant in the object storage, optionally
+// repairing both.
+func (c *ObjectFileControllerImpl) reconcileTenantStorage(ctx context.Context, tenantID primitive.ObjectID, repair bool) (*StorageReconciliationResponseIDO, error) {
+	graceStart := time.Now().Add(-reconcileGracePeriod)
+
+	// STEP 1: Get every object of the tenant from the object storage.
+	objects, err := c.ObjectStorage.ListObjectsByPrefix(ctx, fmt.Sprintf("ten_%v/", tenantID.Hex()))
+	if err != nil {
+		c.Logger.Error("failed listing objects by prefix", slog.Any("error", err))
+		return nil, err
+	}
+	objectsByKey := make(map[string]*object_storage.ObjectSummary, len(objects))
+	for _, obj := range objects {
+		objectsByKey[obj.Key] = obj
+	}
+
+	res := &StorageReconciliationResponseIDO{
+		CheckedObjectCount: len(objects),
+		MissingObjects:     []*MissingObjectIDO{},
+		OrphanedObjects:    []*OrphanedObjectIDO{},
+		Repaired:           repair,
+	}
+
+	// STEP 2: Find the object files whose content is missing.
+	referenced := map[string]bool{}
+	cursor := primitive.NilObjectID
+	for {
+		ofs, err := c.ObjectFileStorer.ListByTenantIDAfterID(ctx, tenantID, cursor, reconcileBatchSize)
+		if err != nil {
+			c.Logger.Error("database list by tenant id after id error", slog.Any("error", err))
+			return nil, err
+		}
+		if len(ofs) == 0 {
+			break
+		}
+		for _, of := range ofs {
+			cursor = of.ID
+			res.CheckedObjectFileCount++
+			for _, key := range of.AllObjectKeys() {
+				referenced[key] = true
+			}
+
+			// Failed uploads never reached the object storage and pending
+			// uploads may still be running.
+			if of.Status == domain.StatusError || (of.Status == domain.StatusPending && of.CreatedAt.After(graceStart)) {
+				continue
+			}
+
+			missing := missingObjects(of, objectsByKey)
+			for _, m := range missing {
+				res.MissingSize += m.Size
+			}
+			res.MissingObjects = append(res.MissingObjects, missing...)
+
+			if repair && objectsByKey[of.ObjectKey] == nil {
+				if err := c.markContentMissing(ctx, of); err != nil {
+					return nil, err
+				}
+				res.MarkedAsErrorCount++
+			}
+		}
+	}
+
+	// STEP 3: Find the objects no object file references.
+	for _, obj := range objects {
+		if referenced[obj.Key] || obj.LastModified.After(graceStart) {
+			continue
+		}
+		res.OrphanedObjects = append(res.OrphanedObjects, &OrphanedObjectIDO{
+			ObjectKey:    obj.Key,
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+		})
+		res.OrphanedSize += obj.Size
+	}
+
+	// STEP 4: Delete the orphaned objects.
+	if repair && len(res.OrphanedObjects) > 0 {
+		deleted, err := c.deleteOrphanedObjects(ctx, res.OrphanedObjects)
+		res.DeletedObjectCount = deleted
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.Logger.Info("storage reconciled",
+		slog.Any("tenant_id", tenantID),
+		slog.Int("missing_objects", len(res.MissingObjects)),
+		slog.Int("orphaned_objects", len(res.OrphanedObjects)),
+		slog.Bool("repair", repair))
+	return res, nil
+}
+
+// missingObjects function returns the content of the object file, current
+// and of every version, which does not exist in the object storage.
+func missingObjects(of *domain.ObjectFile, objectsByKey map[string]*object_storage.ObjectSummary) []*MissingObjectIDO {
+	missing := []*MissingObjectIDO{}
+	seen := map[string]bool{}
+	if of.ObjectKey != "" && objectsByKey[of.ObjectKey] == nil {
+		missing = append(missing, &MissingObjectIDO{
+			ObjectFileID:   of.ID,
+			ObjectFileName: of.Name,
+			ObjectKey:      of.ObjectKey,
+			VersionNumber:  of.CurrentVersion,
+			Size:           of.Size,
+			Status:         of.Status,
+		})
+	}
+	seen[of.ObjectKey] = true
+	for _, v := range of.Versions {
+		if v.ObjectKey == "" || seen[v.ObjectKey] || objectsByKey[v.ObjectKey] != nil {
+			continue
+		}
+		seen[v.ObjectKey] = true
+		missing = append(missing, &MissingObjectIDO{
+			ObjectFileID:   of.ID,
+			ObjectFileName: of.Name,
+			ObjectKey:      v.ObjectKey,
+			VersionNumber:  v.Number,
+			Size:           v.Size,
+			Status:         of.Status,
+		})
+	}
+	return missing
+}
+
+// markContentMissing function marks the object file as a failed upload so
+// staff are asked to upload the file again.
+func (c *ObjectFileControllerImpl) markContentMissing(ctx context.Context, of *domain.ObjectFile) error {
+	before := auditevent_c.Snapshot(of)
+	of.Status = domain.StatusError
+	of.UploadError = missingObjectUploadError
+	of.ModifiedAt = time.Now()
+	if err := c.ObjectFileStorer.UpdateByID(ctx, of); err != nil {
+		c.Logger.Error("database update by id error", slog.Any("error", err))
+		return err
+	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionUpdate,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   of.ID,
+		TargetName: of.Name,
+		Before:     before,
+		After:      of,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return nil
+}
+
+// deleteOrphanedObjects function deletes the objects from the object storage
+// in batches. Returns the number of objects deleted.
+func (c *ObjectFileControllerImpl) deleteOrphanedObjects(ctx context.Context, orphans []*OrphanedObjectIDO) (int, error) {
+	deleted := 0
+	for start := 0; start < len(orphans); start += reconcileDeleteBatchSize {
+		end := min(start+reconcileDeleteBatchSize, len(orphans))
+		keys := make([]string, 0, end-start)
+		for _, o := range orphans[start:end] {
+			keys = append(keys, o.ObjectKey)
+		}
+		if err := c.ObjectStorage.DeleteByKeys(ctx, keys); err != nil {
+			c.Logger.Error("failed deleting orphaned objects", slog.Any("error", err))
+			return deleted, err
+		}
+		deleted += len(keys)
+
+		for _, o := range orphans[start:end] {
+			if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+				Action:     auditevent_s.ActionPurge,
+				TargetType: auditevent_s.TargetTypeStorageObject,
+				TargetName: o.ObjectKey,
+				Before:     o,
+			}); err != nil {
+				c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+			}
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+)
+
+// reconcileTestObjectStorage returns the objects of the bucket and keeps the
+// keys deleted.
+type reconcileTestObjectStorage struct {
+	object_storage.ObjectStorager
+	objects []*object_storage.ObjectSummary
+	deleted []string
+}
+
+func (s *reconcileTestObjectStorage) ListObjectsByPrefix(ctx context.Context, prefix string) ([]*object_storage.ObjectSummary, error) {
+	return s.objects, nil
+}
+
+func (s *reconcileTestObjectStorage) DeleteByKeys(ctx context.Context, keys []string) error {
+	s.deleted = append(s.deleted, keys...)
+	return nil
+}
+
+// reconcileTestObjectFileStorer returns the object files in a single batch.
+type reconcileTestObjectFileStorer struct {
+	domain.ObjectFileStorer
+	objectFiles []*domain.ObjectFile
+	updated     []*domain.ObjectFile
+}
+
+func (s *reconcileTestObjectFileStorer) ListByTenantIDAfterID(ctx context.Context, tenantID primitive.ObjectID, cursor primitive.ObjectID, limit int64) ([]*domain.ObjectFile, error) {
+	if !cursor.IsZero() {
+		return nil, nil
+	}
+	return s.objectFiles, nil
+}
+
+func (s *reconcileTestObjectFileStorer) UpdateByID(ctx context.Context, of *domain.ObjectFile) error {
+	s.updated = append(s.updated, of)
+	return nil
+}
+
+func TestReconcileStorage(t *testing.T) {
+	owner := primitive.NewObjectID()
+	old := time.Now().Add(-48 * time.Hour)
+	intact := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "intact", CreatedAt: old}
+	missing := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "missing", Size: 7, CreatedAt: old}
+	failed := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusError, ObjectKey: "failed", CreatedAt: old}
+	storage := &reconcileTestObjectStorage{
+		objects: []*object_storage.ObjectSummary{
+			{Key: "intact", Size: 3, LastModified: old},
+			{Key: "orphan", Size: 5, LastModified: old},
+			{Key: "uploading", Size: 9, LastModified: time.Now()},
+		},
+	}
+	storer := &reconcileTestObjectFileStorer{objectFiles: []*domain.ObjectFile{intact, missing, failed}}
+	c := &ObjectFileControllerImpl{
+		Logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ObjectStorage:    storage,
+		ObjectFileStorer: storer,
+		AuditEvent:       &fakeAuditEvent{},
+	}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+
+	// The report must not modify anything.
+	res, err := c.ReconcileStorage(ctx, &StorageReconciliationRequestIDO{})
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if len(res.MissingObjects) != 1 || res.MissingObjects[0].ObjectFileID != missing.ID || res.MissingSize != 7 {
+		t.Errorf("unexpected missing objects %+v", res.MissingObjects)
+	}
+	if len(res.OrphanedObjects) != 1 || res.OrphanedObjects[0].ObjectKey != "orphan" || res.OrphanedSize != 5 {
+		t.Errorf("unexpected orphaned objects %+v", res.OrphanedObjects)
+	}
+	if len(storer.updated) != 0 || len(storage.deleted) != 0 {
+		t.Error("report modified the records or the object storage")
+	}
+
+	res, err = c.ReconcileStorage(ctx, &StorageReconciliationRequestIDO{Repair: true})
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if res.MarkedAsErrorCount != 1 || missing.Status != domain.StatusError {
+		t.Errorf("expected object file with missing content marked as error but received %+v", res)
+	}
+	if res.DeletedObjectCount != 1 || len(storage.deleted) != 1 || storage.deleted[0] != "orphan" {
+		t.Errorf("expected orphaned object deleted but deleted %v", storage.deleted)
+	}
+}
+
+func TestReconcileStorageRequiresExecutive(t *testing.T) {
+	c := &ObjectFileControllerImpl{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	ctx := newTenancyTestContext(primitive.NewObjectID(), user_d.UserRoleManagement)
+
+	_, err := c.ReconcileStorage(ctx, &StorageReconciliationRequestIDO{Repair: true})
+	assertForbidden(t, err)
+}
+
+func TestReconcileAllStorageOnlyReports(t *testing.T) {
+	owner := primitive.NewObjectID()
+	old := time.Now().Add(-48 * time.Hour)
+	missing := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "missing", CreatedAt: old}
+	storage := &reconcileTestObjectStorage{
+		objects: []*object_storage.ObjectSummary{{Key: "orphan", Size: 5, LastModified: old}},
+	}
+	storer := &reconcileTestObjectFileStorer{objectFiles: []*domain.ObjectFile{missing}}
+	c := &ObjectFileControllerImpl{
+		Logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ObjectStorage:    storage,
+		ObjectFileStorer: storer,
+		TenantStorer:     &fakeTenantStorer{tenant: &tenant_s.Tenant{ID: owner}},
+	}
+
+	missingCount, orphanedCount, err := c.ReconcileAllStorage(context.Background())
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if missingCount != 1 || orphanedCount != 1 {
+		t.Errorf("expected 1 missing and 1 orphaned object but received %d and %d", missingCount, orphanedCount)
+	}
+	if len(storer.updated) != 0 || len(storage.deleted) != 0 {
+		t.Error("report modified the records or the object storage")
+	}
+}
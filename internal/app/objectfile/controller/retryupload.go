@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+type ObjectFileRetryUploadRequestIDO struct {
+	ID             primitive.ObjectID
+	FileName       string
+	FileType       string
+	File           io.Reader // Streamed into the object storage, it is read only once.
+	AllowDuplicate bool      // Optional. If true, a file with the same content as an existing file is accepted but flagged.
+}
+
+func validateRetryUploadRequest(dirtyData *ObjectFileRetryUploadRequestIDO) error {
+	e := make(map[string]string)
+
+	if dirtyData.ID.IsZero() {
+		e["id"] = "missing value"
+	}
+	if dirtyData.FileName == "" {
+		e["file"] = "missing value"
+	}
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// RetryUploadByID function uploads the file again for an object file whose
+// upload failed. The record created by the failed upload is reused so its
+// name, description, smart folder and classification are kept.
+func (c *ObjectFileControllerImpl) RetryUploadByID(ctx context.Context, req *ObjectFileRetryUploadRequestIDO) (*domain.ObjectFile, error) {
+	// Extract from our session the following data.
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName, _ := ctx.Value(constants.SessionUserName).(string)
+
+	if err := validateRetryUploadRequest(req); err != nil {
+		return nil, err
+	}
+
+	of, err := c.ObjectFileStorer.GetByID(ctx, req.ID)
+	if err != nil {
+		c.Logger.Error("database get by id error",
+			slog.Any("error", err),
+			slog.Any("object_file_id", req.ID))
+		return nil, err
+	}
+	if of == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := c.authorizeObjectFile(ctx, of, policy.PermissionUpload); err != nil {
+		return nil, err
+	}
+	if err := requireNotTrashed(of); err != nil {
+		return nil, err
+	}
+	if err := policy.AuthorizeVerifiedEmailForTenant(ctx, c.TenantStorer, c.Logger); err != nil {
+		return nil, err
+	}
+
+	// Files which were uploaded once keep their version history so a new
+	// version must be uploaded instead.
+	if of.Status != domain.StatusError || len(of.Versions) > 0 {
+		return nil, httperror.NewForSingleField(http.StatusConflict, "status", "only failed uploads can be retried")
+	}
+
+	// Mark the upload as pending so concurrent retries conflict. The stalled
+	// uploads job settles the record if this server stops during the upload.
+	claimed, err := c.ObjectFileStorer.UpdateStatusByID(ctx, of.ID, domain.StatusError, domain.StatusPending)
+	if err != nil {
+		c.Logger.Error("database update status by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if !claimed {
+		return nil, httperror.NewForSingleField(http.StatusConflict, "status", "object file upload is already being retried")
+	}
+	before := auditevent_c.Snapshot(of)
+
+	// Every upload gets its own key so a late write of the failed upload can
+	// never overwrite this one.
+	objectKey := c.generateObjectKey(of.TenantID, of.SmartFolderCategory, of.SmartFolderSubCategory, of.Classification)
+
+	c.Logger.Debug("beginning private object file upload retry...",
+		slog.Any("object_file_id", of.ID),
+		slog.String("object_key", objectKey))
+	info, uploadErr := c.uploadStream(ctx, objectKey, req.File, req.FileName)
+	var httpErr httperror.HTTPError
+	if errors.As(uploadErr, &httpErr) {
+		// The upload was rejected, not failed, so leave the record as it was.
+		c.releaseRetryClaim(ctx, of)
+		return nil, uploadErr
+	}
+	var dup *domain.ObjectFile
+	if uploadErr == nil {
+		// Reject the upload if its content exceeds the quota or duplicates
+		// another file.
+		dup, err = c.acceptUpload(ctx, of.TenantID, of.ID, info, req.AllowDuplicate)
+		if err != nil {
+			c.discardUpload(ctx, objectKey)
+			c.releaseRetryClaim(ctx, of)
+			return nil, err
+		}
+	}
+
+	of.ObjectKey = objectKey
+	of.ObjectKeyLayout = domain.ObjectKeyLayoutUnique
+	of.Filename = req.FileName
+	of.FileType = req.FileType
+	of.DuplicateOfObjectFileID = primitive.NilObjectID
+	if dup != nil {
+		of.DuplicateOfObjectFileID = dup.ID
+	}
+	of.UploadAttemptCount++
+	of.ModifiedAt = time.Now()
+	of.ModifiedByUserID = userID
+	of.ModifiedByUserName = userName
+	if uploadErr != nil {
+		c.Logger.Error("private object file upload error",
+			slog.Any("object_file_id", of.ID),
+			slog.Any("error", uploadErr))
+		of.Status = domain.StatusError
+		of.UploadError = uploadErr.Error()
+	} else {
+		activateObjectFile(of, info)
+		of.UploadError = ""
+		of.Versions[0].UploadedByUserID = userID
+		of.Versions[0].UploadedByUserName = userName
+	}
+
+	// Save the real state of the upload. The request may have been cancelled
+	// during the upload so do not let the cancellation skip this save.
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := c.ObjectFileStorer.UpdateByID(saveCtx, of); err != nil {
+		c.Logger.Error("database update by id error", slog.Any("error", err))
+		return nil, err
+	}
+
+	if uploadErr != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadGateway, "file", "failed uploading file, please try again")
+	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionUpdate,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   of.ID,
+		TargetName: of.Name,
+		Before:     before,
+		After:      of,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return of, nil
+}
+
+// releaseRetryClaim function marks the object file as failed again after its
+// retried upload was rejected.
+func (c *ObjectFileControllerImpl) releaseRetryClaim(ctx context.Context, of *domain.ObjectFile) {
+	if _, err := c.ObjectFileStorer.UpdateStatusByID(context.WithoutCancel(ctx), of.ID, domain.StatusPending, domain.StatusError); err != nil {
+		c.Logger.Error("failed releasing object file upload retry",
+			slog.Any("object_file_id", of.ID),
+			slog.Any("error", err))
+	}
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// retryTestObjectFileStorer keeps the status changes and the saved record of
+// a single object file.
+type retryTestObjectFileStorer struct {
+	fakeObjectFileStorer
+	saved *domain.ObjectFile
+}
+
+func (s *retryTestObjectFileStorer) UpdateStatusByID(ctx context.Context, id primitive.ObjectID, from int8, to int8) (bool, error) {
+	if s.objectFile.Status != from {
+		return false, nil
+	}
+	s.objectFile.Status = to
+	return true, nil
+}
+
+func (s *retryTestObjectFileStorer) UpdateByID(ctx context.Context, m *domain.ObjectFile) error {
+	s.saved = m
+	return nil
+}
+
+func (s *retryTestObjectFileStorer) GetByTenantIDAndSHA256(ctx context.Context, tenantID primitive.ObjectID, sha256 string) (*domain.ObjectFile, error) {
+	return nil, nil
+}
+
+func newRetryUploadTestController(t *testing.T, of *domain.ObjectFile) (*ObjectFileControllerImpl, *retryTestObjectFileStorer) {
+	c, _ := newTenancyTestController(t, of)
+	storer := &retryTestObjectFileStorer{fakeObjectFileStorer: fakeObjectFileStorer{objectFile: of}}
+	c.ObjectFileStorer = storer
+	c.ObjectStorage = &streamTestObjectStorage{}
+	c.UUID = uuid.NewProvider()
+	c.AuditEvent = &fakeAuditEvent{}
+	c.TenantStorer = &fakeTenantStorer{tenant: &tenant_s.Tenant{ID: of.TenantID}}
+	c.Config = &config.Conf{}
+	c.Config.AppServer.MaxUploadSizeInMB = 1
+	return c, storer
+}
+
+func TestRetryUploadReusesFailedRecord(t *testing.T) {
+	owner := primitive.NewObjectID()
+	of := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusError, ObjectKey: "failed", UploadAttemptCount: 1, UploadError: "timeout"}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+	c, storer := newRetryUploadTestController(t, of)
+
+	res, err := c.RetryUploadByID(ctx, &ObjectFileRetryUploadRequestIDO{
+		ID:       of.ID,
+		FileName: "hello.txt",
+		File:     strings.NewReader("hello world"),
+	})
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if res.ID != of.ID || storer.saved != res {
+		t.Error("expected the failed record to be reused")
+	}
+	if res.Status != domain.StatusActive || res.UploadError != "" || res.UploadAttemptCount != 2 {
+		t.Errorf("expected an active object file after the second attempt but received status %d, error %q and %d attempts", res.Status, res.UploadError, res.UploadAttemptCount)
+	}
+	if res.ObjectKey == "failed" || res.Size != 11 {
+		t.Errorf("expected the content under a new key but received %q with %d bytes", res.ObjectKey, res.Size)
+	}
+}
+
+func TestRetryUploadRejectsUploadedObjectFile(t *testing.T) {
+	owner := primitive.NewObjectID()
+	of := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "key"}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+	c, storer := newRetryUploadTestController(t, of)
+
+	_, err := c.RetryUploadByID(ctx, &ObjectFileRetryUploadRequestIDO{
+		ID:       of.ID,
+		FileName: "hello.txt",
+		File:     strings.NewReader("hello world"),
+	})
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusConflict {
+		t.Errorf("expected conflict error but received %v", err)
+	}
+	if storer.saved != nil || of.Status != domain.StatusActive {
+		t.Error("object file must not be changed")
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+)
+
+const (
+	// stalledUploadAge is how long an upload may stay pending before it is
+	// considered interrupted; uploads are retried within the request so a
+	// healthy upload never stays pending for this long.
+	stalledUploadAge = time.Hour
+
+	// stalledUploadsBatchSize is the maximum number of stalled uploads handled
+	// per run.
+	stalledUploadsBatchSize = 100
+)
+
+// RecoverStalledUploads function settles the object files whose upload was
+// interrupted, for example by a restart of the server, and which would
+// otherwise stay pending forever. If the content reached the object storage
+// the object file is activated, else the upload is marked as failed so staff
+// are asked to retry the upload with the file; the content is not kept by the
+// server so it cannot be uploaded again without them. Returns the number of
+// recovered and failed uploads.
+func (c *ObjectFileControllerImpl) RecoverStalledUploads(ctx context.Context) (int, int, error) {
+	ofs, err := c.ObjectFileStorer.ListStalledUploads(ctx, time.Now().Add(-stalledUploadAge), stalledUploadsBatchSize)
+	if err != nil {
+		c.Logger.Error("database list stalled uploads error", slog.Any("error", err))
+		return 0, 0, err
+	}
+
+	recovered, failed := 0, 0
+	for _, of := range ofs {
+		exists, err := c.ObjectStorage.ObjectExists(ctx, of.ObjectKey)
+		if err != nil {
+			c.Logger.Error("failed checking object in object storage",
+				slog.Any("object_file_id", of.ID),
+				slog.Any("error", err))
+			return recovered, failed, err
+		}
+
+		if exists {
+			// The details of the content are only saved once the upload
+			// finished so compute them from the object storage.
+			info, err := c.inspectObject(ctx, of.ObjectKey, of.Filename)
+			if err != nil {
+				c.Logger.Error("failed inspecting object in object storage",
+					slog.Any("object_file_id", of.ID),
+					slog.Any("error", err))
+				return recovered, failed, err
+			}
+			if info.Size != of.Size {
+				// The usage was reserved for the declared size, if any.
+				if err := c.TenantStorer.AdjustUsage(ctx, of.TenantID, info.Size-of.Size, 0); err != nil {
+					c.Logger.Warn("failed adjusting tenant usage",
+						slog.Any("tenant_id", of.TenantID),
+						slog.Any("error", err))
+				}
+			}
+			activateObjectFile(of, info)
+		} else {
+			of.Status = domain.StatusError
+			of.UploadError = "upload was interrupted"
+			c.releaseUsage(ctx, of.TenantID, of.Size, 0)
+		}
+		of.ModifiedAt = time.Now()
+
+		if err := c.ObjectFileStorer.UpdateByID(ctx, of); err != nil {
+			c.Logger.Error("database update by id error", slog.Any("error", err))
+			return recovered, failed, err
+		}
+		if exists {
+			recovered++
+		} else {
+			failed++
+		}
+	}
+	return recovered, failed, nil
+}
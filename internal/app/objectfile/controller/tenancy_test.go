@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeObjectFileStorer returns a single object file and records which
+// destructive calls were made. Unimplemented methods panic.
+type fakeObjectFileStorer struct {
+	domain.ObjectFileStorer
+	objectFile *domain.ObjectFile
+	lastFilter *domain.ObjectFileListFilter
+	deleted    bool
+}
+
+func (s *fakeObjectFileStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error) {
+	return s.objectFile, nil
+}
+
+func (s *fakeObjectFileStorer) ListByFilter(ctx context.Context, f *domain.ObjectFileListFilter) (*domain.ObjectFileListResult, error) {
+	s.lastFilter = f
+	return &domain.ObjectFileListResult{}, nil
+}
+
+func (s *fakeObjectFileStorer) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	s.deleted = true
+	return nil
+}
+
+// fakeSmartFolderStorer returns a single smart folder and the smart folders
+// hidden from every user.
+type fakeSmartFolderStorer struct {
+	smartfolder_s.SmartFolderStorer
+	smartFolder *smartfolder_s.SmartFolder
+	hiddenIDs   []primitive.ObjectID
+}
+
+func (s *fakeSmartFolderStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error) {
+	return s.smartFolder, nil
+}
+
+func (s *fakeSmartFolderStorer) ListIDsHiddenFromUser(ctx context.Context, tid primitive.ObjectID, uid primitive.ObjectID) ([]primitive.ObjectID, error) {
+	return s.hiddenIDs, nil
+}
+
+// fakeObjectStorage fails the test if the storage is reached.
+type fakeObjectStorage struct {
+	object_storage.ObjectStorager
+	t *testing.T
+}
+
+func (s *fakeObjectStorage) GetBinaryData(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	s.t.Error("object storage must not be reached for another tenant's object file")
+	return nil, errors.New("unexpected call")
+}
+
+func newTenancyTestController(t *testing.T, of *domain.ObjectFile) (*ObjectFileControllerImpl, *fakeObjectFileStorer) {
+	storer := &fakeObjectFileStorer{objectFile: of}
+	return &ObjectFileControllerImpl{
+		Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ObjectStorage:     &fakeObjectStorage{t: t},
+		SmartFolderStorer: &fakeSmartFolderStorer{},
+		ObjectFileStorer:  storer,
+	}, storer
+}
+
+func newTenancyTestContext(tenantID primitive.ObjectID, role int8) context.Context {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, constants.SessionUserTenantID, tenantID)
+	ctx = context.WithValue(ctx, constants.SessionUserID, primitive.NewObjectID())
+	ctx = context.WithValue(ctx, constants.SessionUserRole, role)
+	return ctx
+}
+
+func assertForbidden(t *testing.T, err error) {
+	t.Helper()
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+		t.Errorf("expected forbidden error but received %v", err)
+	}
+}
+
+func TestCrossTenantObjectFileAccessIsForbidden(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	of := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "key"}
+
+	// Executives are scoped to the tenant of their session as well.
+	for _, role := range []int8{user_d.UserRoleExecutive, user_d.UserRoleManagement, user_d.UserRoleFrontlineStaff} {
+		ctx := newTenancyTestContext(other, role)
+		c, storer := newTenancyTestController(t, of)
+
+		_, err := c.GetByID(ctx, of.ID)
+		assertForbidden(t, err)
+
+		_, err = c.GetContent(ctx, of.ID)
+		assertForbidden(t, err)
+
+		_, err = c.GetPresignedURLByID(ctx, of.ID)
+		assertForbidden(t, err)
+
+		_, err = c.ListVersionsByID(ctx, of.ID)
+		assertForbidden(t, err)
+
+		_, err = c.GetVersionContentByID(ctx, of.ID, 1)
+		assertForbidden(t, err)
+
+		assertForbidden(t, c.DeleteByID(ctx, of.ID))
+		if storer.deleted {
+			t.Error("object file of another tenant was deleted")
+		}
+	}
+}
+
+func TestSameTenantObjectFileAccessIsAllowed(t *testing.T) {
+	owner := primitive.NewObjectID()
+	of := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "key"}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleFrontlineStaff)
+	c, _ := newTenancyTestController(t, of)
+
+	res, err := c.GetByID(ctx, of.ID)
+	if err != nil || res == nil {
+		t.Errorf("expected object file but received %v, %v", res, err)
+	}
+}
+
+func TestObjectFileListIsScopedToSessionTenant(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	ctx := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+	c, storer := newTenancyTestController(t, nil)
+
+	// Requesting another tenant's files must be overridden.
+	if _, err := c.ListByFilter(ctx, &domain.ObjectFileListFilter{TenantID: other}); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if storer.lastFilter.TenantID != owner {
+		t.Errorf("expected list to be scoped to %v but was %v", owner, storer.lastFilter.TenantID)
+	}
+}
+
+func TestRestrictedSmartFolderHidesObjectFiles(t *testing.T) {
+	owner := primitive.NewObjectID()
+	sf := &smartfolder_s.SmartFolder{
+		ID:       primitive.NewObjectID(),
+		TenantID: owner,
+		AccessControlList: []*policy.AccessControlEntry{
+			{UserID: primitive.NewObjectID(), Permissions: []int8{policy.PermissionView}},
+		},
+	}
+	of := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, SmartFolderID: sf.ID, Status: domain.StatusActive, ObjectKey: "key"}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleFrontlineStaff)
+	c, storer := newTenancyTestController(t, of)
+	c.SmartFolderStorer = &fakeSmartFolderStorer{smartFolder: sf, hiddenIDs: []primitive.ObjectID{sf.ID}}
+
+	_, err := c.GetByID(ctx, of.ID)
+	assertForbidden(t, err)
+
+	_, err = c.GetContent(ctx, of.ID)
+	assertForbidden(t, err)
+
+	if _, err := c.ListByFilter(ctx, &domain.ObjectFileListFilter{}); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if len(storer.lastFilter.ExcludeSmartFolderIDs) != 1 || storer.lastFilter.ExcludeSmartFolderIDs[0] != sf.ID {
+		t.Errorf("expected list to exclude %v but excluded %v", sf.ID, storer.lastFilter.ExcludeSmartFolderIDs)
+	}
+}
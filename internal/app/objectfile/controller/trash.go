@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// requireNotTrashed function returns an error if the object file was moved to
+// the trash, in which case it must be restored before being used again.
+func requireNotTrashed(of *domain.ObjectFile) error {
+	if of.IsTrashed() {
+		return httperror.NewForSingleField(http.StatusNotFound, "id", "object file is in the trash")
+	}
+	return nil
+}
+
+// trashRetention returns how long trashed records are kept before purged.
+func (c *ObjectFileControllerImpl) trashRetention() time.Duration {
+	return time.Duration(c.Config.AppServer.TrashRetentionInDays) * 24 * time.Hour
+}
+
+// getTrashedObjectFile function returns the trashed object file if the
+// authenticated user is granted the delete permission on it.
+func (c *ObjectFileControllerImpl) getTrashedObjectFile(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error) {
+	of, err := c.ObjectFileStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error",
+			slog.String("object_file_id", id.Hex()),
+			slog.Any("error", err))
+		return nil, err
+	}
+	if of == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := c.authorizeObjectFile(ctx, of, policy.PermissionDelete); err != nil {
+		return nil, err
+	}
+	if !of.IsTrashed() {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "object file is not in the trash")
+	}
+	return of, nil
+}
+
+// ListTrashByFilter function returns the object files in the trash of the
+// tenant of the authenticated user.
+func (c *ObjectFileControllerImpl) ListTrashByFilter(ctx context.Context, f *domain.ObjectFileListFilter) (*domain.ObjectFileListResult, error) {
+	// Apply protection based on ownership.
+	f.TenantID = policy.SessionTenantID(ctx) // Force tenant tenancy restrictions.
+	f.Trashed = true
+	if err := policy.Authorize(ctx, policy.PermissionDelete); err != nil {
+		return nil, err
+	}
+	hidden, err := c.hiddenSmartFolderIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f.ExcludeSmartFolderIDs = hidden
+
+	res, err := c.ObjectFileStorer.ListByFilter(ctx, f)
+	if err != nil {
+		c.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return res, nil
+}
+
+// RestoreByID function takes the object file out of the trash.
+func (c *ObjectFileControllerImpl) RestoreByID(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error) {
+	of, err := c.getTrashedObjectFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if of.DeletedWithSmartFolder {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "object file was deleted with its smart folder, restore the smart folder instead")
+	}
+	sf, err := c.SmartFolderStorer.GetByID(ctx, of.SmartFolderID)
+	if err != nil {
+		c.Logger.Error("failed getting smart folder", slog.Any("error", err))
+		return nil, err
+	}
+	if sf == nil || sf.IsTrashed() {
+		return nil, httperror.NewForBadRequestWithSingleField("smart_folder_id", "smart folder does not exist")
+	}
+
+	before := auditevent_c.Snapshot(of)
+	of.DeletedAt = time.Time{}
+	of.DeletedByUserID = primitive.NilObjectID
+	of.DeletedByUserName = ""
+	of.PurgeAt = time.Time{}
+
+	if err := c.ObjectFileStorer.UpdateByID(ctx, of); err != nil {
+		c.Logger.Error("database update by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionRestore,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   of.ID,
+		TargetName: of.Name,
+		Before:     before,
+		After:      of,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return of, nil
+}
+
+// PurgeByID function permanently deletes the trashed object file and the
+// content of every one of its versions.
+func (c *ObjectFileControllerImpl) PurgeByID(ctx context.Context, id primitive.ObjectID) error {
+	of, err := c.getTrashedObjectFile(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := c.purge(ctx, of); err != nil {
+		return err
+	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionPurge,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   of.ID,
+		TargetName: of.Name,
+		Before:     of,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return nil
+}
+
+// PurgeExpiredTrash function permanently deletes the object files of every
+// tenant which were kept in the trash for the entire retention window and
+// returns how many were purged.
+func (c *ObjectFileControllerImpl) PurgeExpiredTrash(ctx context.Context) (int, error) {
+	ids, err := c.ObjectFileStorer.ListIDsToPurge(ctx, time.Now())
+	if err != nil {
+		c.Logger.Error("failed listing object files to purge", slog.Any("error", err))
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		of, err := c.ObjectFileStorer.GetByID(ctx, id)
+		if err != nil {
+			return purged, err
+		}
+		if of == nil || !of.IsTrashed() { // Restored in the meantime.
+			continue
+		}
+		if err := c.purge(ctx, of); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge function deletes the content of every version of the object file
+// from the object storage and then the record.
+func (c *ObjectFileControllerImpl) purge(ctx context.Context, of *domain.ObjectFile) error {
+	if err := c.ObjectStorage.DeleteByKeys(ctx, of.AllObjectKeys()); err != nil {
+		c.Logger.Warn("object delete by keys error", slog.Any("error", err))
+		// Do not return an error, simply continue this function as there might
+		// be a case were the file was removed on the object bucket by ourselves
+		// or some other reason.
+	}
+	c.Logger.Debug("deleted from remote object storage", slog.String("object_file_id", of.ID.Hex()))
+
+	if err := c.ObjectFileStorer.DeleteByID(ctx, of.ID); err != nil {
+		c.Logger.Error("database delete by id error", slog.Any("error", err))
+		return err
+	}
+	c.Logger.Debug("deleted from database", slog.String("object_file_id", of.ID.Hex()))
+
+	c.releaseUsage(ctx, of.TenantID, of.ComputeStoredSize(), 1)
+	return nil
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// trashTestObjectFileStorer keeps the object file updated by the controller.
+type trashTestObjectFileStorer struct {
+	fakeObjectFileStorer
+}
+
+func (s *trashTestObjectFileStorer) UpdateByID(ctx context.Context, of *domain.ObjectFile) error {
+	s.objectFile = of
+	return nil
+}
+
+// fakeAuditEvent discards every audit event.
+type fakeAuditEvent struct {
+	auditevent_c.AuditEventController
+}
+
+func (a *fakeAuditEvent) Record(ctx context.Context, req *auditevent_c.AuditEventRecordRequestIDO) error {
+	return nil
+}
+
+func TestDeleteMovesObjectFileToTrash(t *testing.T) {
+	owner := primitive.NewObjectID()
+	of := &domain.ObjectFile{ID: primitive.NewObjectID(), TenantID: owner, Status: domain.StatusActive, ObjectKey: "key"}
+	ctx := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+	c, _ := newTenancyTestController(t, of)
+	storer := &trashTestObjectFileStorer{fakeObjectFileStorer{objectFile: of}}
+	c.ObjectFileStorer = storer
+	c.AuditEvent = &fakeAuditEvent{}
+	c.Config = &config.Conf{}
+	c.Config.AppServer.TrashRetentionInDays = 30
+
+	if err := c.DeleteByID(ctx, of.ID); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if storer.deleted {
+		t.Error("object file was deleted instead of moved to the trash")
+	}
+	if !storer.objectFile.IsTrashed() || !storer.objectFile.PurgeAt.After(storer.objectFile.DeletedAt) {
+		t.Errorf("expected object file in the trash but received %+v", storer.objectFile)
+	}
+
+	// Trashed object files are not found until restored.
+	_, err := c.GetByID(ctx, of.ID)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusNotFound {
+		t.Errorf("expected not found error but received %v", err)
+	}
+}
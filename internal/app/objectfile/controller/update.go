@@ -2,17 +2,21 @@ package controller
 
 import (
 	"context"
-	"fmt"
-	"mime/multipart"
+	"errors"
+	"io"
+	"net/http"
 	"time"
 
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
-	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
@@ -24,9 +28,10 @@ type ObjectFileUpdateRequestIDO struct {
 	OwnershipType  int8
 	FileName       string
 	FileType       string
-	File           multipart.File
+	File           io.Reader // Optional. Streamed into the object storage, it is read only once.
 	SmartFolderID  primitive.ObjectID
 	Classification uint64
+	AllowDuplicate bool // Optional. If true, a file with the same content as an existing file is accepted but flagged.
 }
 
 func ValidateUpdateRequest(dirtyData *ObjectFileUpdateRequestIDO) error {
@@ -71,54 +76,46 @@ func (c *ObjectFileControllerImpl) UpdateByID(ctx context.Context, req *ObjectFi
 			slog.Any("objectfile_id", req.ID))
 		return nil, httperror.NewForBadRequestWithSingleField("message", "objectfile does not exist")
 	}
+	if err := c.authorizeObjectFile(ctx, os, policy.PermissionEdit); err != nil {
+		return nil, err
+	}
+	if err := requireNotTrashed(os); err != nil {
+		return nil, err
+	}
 
 	// Extract from our session the following data.
 	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
-	userTenantID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
-	userRole := ctx.Value(constants.SessionUserRole).(int8)
 	userName := ctx.Value(constants.SessionUserName).(string)
 
-	// If user is not administrator nor belongs to the objectfile then error.
-	if userRole != user_d.UserRoleExecutive {
-		c.Logger.Error("authenticated user is not staff role nor belongs to the objectfile error",
-			slog.Any("userRole", userRole),
-			slog.Any("userTenantID", userTenantID))
-		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not belong to this objectfile")
-	}
-
 	sf, err := c.SmartFolderStorer.GetByID(ctx, req.SmartFolderID)
 	if err != nil {
 		c.Logger.Error("failed getting smart folder", slog.Any("error", err))
 		return nil, err
 	}
+	if sf == nil || sf.IsTrashed() {
+		return nil, httperror.NewForBadRequestWithSingleField("smart_folder_id", "does not exist")
+	}
+	if err := policy.AuthorizeRecord(ctx, sf.TenantID, sf.AccessControlList, policy.PermissionEdit); err != nil {
+		c.Logger.Warn("smart folder access denied", slog.Any("smart_folder_id", sf.ID))
+		return nil, err
+	}
 
-	// Update the file if the user uploaded a new file.
+	// Upload the file if the user uploaded a new file. The upload happens
+	// before the record is updated so a slow upload does not hold the
+	// transaction below open.
+	var (
+		objectKey string
+		info      *fileInspection
+		dup       *domain.ObjectFile
+	)
 	if req.File != nil {
-		// Proceed to delete the physical files from AWS object.
-		if err := c.ObjectStorage.DeleteByKeys(ctx, []string{os.ObjectKey}); err != nil {
-			c.Logger.Warn("object delete by keys error", slog.Any("error", err))
-			// Do not return an error, simply continue this function as there might
-			// be a case were the file was removed on the object bucket by ourselves
-			// or some other reason.
+		if err := policy.AuthorizeVerifiedEmailForTenant(ctx, c.TenantStorer, c.Logger); err != nil {
+			return nil, err
 		}
 
-		// Generate the key of our upload.
-		objectKey := fmt.Sprintf("ten_%v/cat_%d/subcat_%d/class_%d/%v", orgID.Hex(), sf.Category, sf.SubCategory, req.Classification, req.FileName)
-
-		go func(file multipart.File, objkey string) {
-			c.Logger.Debug("beginning private object image upload...")
-			if err := c.ObjectStorage.UploadContentFromMulipart(context.Background(), objkey, file); err != nil {
-				c.Logger.Error("private object upload error", slog.Any("error", err))
-				// Do not return an error, simply continue this function as there might
-				// be a case were the file was removed on the object bucket by ourselves
-				// or some other reason.
-			}
-			c.Logger.Debug("Finished private object image upload")
-		}(req.File, objectKey)
-
-		// Update file.
-		os.ObjectKey = objectKey
-		os.Filename = req.FileName
+		// Generate the key of our upload. Every version gets its own key so
+		// previous versions are never overwritten.
+		objectKey = c.generateObjectKey(orgID, sf.Category, sf.SubCategory, req.Classification)
 
 		c.Logger.Debug("pre-upload meta",
 			slog.String("file_name", req.FileName),
@@ -129,25 +126,133 @@ func (c *ObjectFileControllerImpl) UpdateByID(ctx context.Context, req *ObjectFi
 			slog.Any("smart_folder_id", sf.ID),
 			slog.Any("classification", req.Classification),
 		)
+
+		// Stream the new file into the object storage and wait for the
+		// upload to finish. If the upload fails then the original file is
+		// left untouched so the record keeps pointing to content which
+		// exists.
+		c.Logger.Debug("beginning private object file upload...")
+		info, err = c.uploadStream(ctx, objectKey, req.File, req.FileName)
+		if err != nil {
+			c.Logger.Error("private object file upload error",
+				slog.Any("object_file_id", os.ID),
+				slog.Any("error", err))
+			var httpErr httperror.HTTPError
+			if errors.As(err, &httpErr) {
+				return nil, err
+			}
+			return nil, httperror.NewForSingleField(http.StatusBadGateway, "file", "failed uploading file, please try again")
+		}
+
+		// Previous versions are kept so the new content is counted in full
+		// against the storage quota of the tenant. Reject or flag files
+		// which were already uploaded to the tenant.
+		dup, err = c.acceptUpload(ctx, os.TenantID, os.ID, info, req.AllowDuplicate)
+		if err != nil {
+			c.discardUpload(ctx, objectKey)
+			return nil, err
+		}
+		c.Logger.Debug("Finished private object file upload")
 	}
 
-	// Modify our original objectfile.
-	os.ModifiedAt = time.Now()
-	os.ModifiedByUserID = userID
-	os.ModifiedByUserName = userName
-	os.Name = req.Name
-	os.Description = req.Description
-	os.SmartFolderID = sf.ID
-	os.SmartFolderName = sf.Name
-	os.SmartFolderCategory = sf.Category
-	os.SmartFolderSubCategory = sf.SubCategory
-	os.Classification = req.Classification
-
-	// Save to the database the modified objectfile.
-	if err := c.ObjectFileStorer.UpdateByID(ctx, os); err != nil {
-		c.Logger.Error("database update by id error", slog.Any("error", err))
+	// DEVELOPERS NOTE:
+	// We run inside a transaction so concurrent updates or restores conflict
+	// instead of silently assigning the same version number.
+	session, err := c.DbClient.StartSession()
+	if err != nil {
+		c.Logger.Error("start session error", slog.Any("error", err))
+		c.discardAcceptedUpload(ctx, os.TenantID, objectKey, info)
 		return nil, err
 	}
+	defer session.EndSession(ctx)
+
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// Re-read the object file as another update may have added a version
+		// while the file was uploading.
+		of, err := c.getObjectFileForVersioning(sessCtx, req.ID, policy.PermissionEdit)
+		if err != nil {
+			return nil, err
+		}
+		before := auditevent_c.Snapshot(of)
+
+		if info != nil {
+			versionNumber := len(of.Versions) + 1
+
+			// DEVELOPERS NOTE:
+			// The previous content is intentionally not deleted from the
+			// object storage as it is kept in the version history.
+
+			// Update file.
+			of.ObjectKey = objectKey
+			of.ObjectKeyLayout = domain.ObjectKeyLayoutUnique
+			of.Filename = req.FileName
+			of.FileType = req.FileType
+			of.MimeType = info.MimeType
+			of.Size = info.Size
+			of.SHA256 = info.SHA256
+			of.ContentType = contentTypeFromMimeType(info.MimeType)
+			of.DuplicateOfObjectFileID = primitive.NilObjectID
+			if dup != nil {
+				of.DuplicateOfObjectFileID = dup.ID
+			}
+			of.Status = domain.StatusActive
+			of.UploadAttemptCount = 1
+			of.UploadError = ""
+			of.UploadedAt = time.Now()
+			of.CurrentVersion = versionNumber
+			of.Versions = append(of.Versions, &domain.ObjectFileVersion{
+				Number:             versionNumber,
+				ObjectKey:          objectKey,
+				Filename:           req.FileName,
+				MimeType:           info.MimeType,
+				Size:               info.Size,
+				SHA256:             info.SHA256,
+				UploadedByUserID:   userID,
+				UploadedByUserName: userName,
+				UploadedAt:         of.UploadedAt,
+			})
+		}
+
+		// Modify our original objectfile.
+		of.ModifiedAt = time.Now()
+		of.ModifiedByUserID = userID
+		of.ModifiedByUserName = userName
+		of.Name = req.Name
+		of.Description = req.Description
+		of.SmartFolderID = sf.ID
+		of.SmartFolderName = sf.Name
+		of.SmartFolderCategory = sf.Category
+		of.SmartFolderSubCategory = sf.SubCategory
+		of.Classification = req.Classification
+
+		// Save to the database the modified objectfile.
+		if err := c.ObjectFileStorer.UpdateByID(sessCtx, of); err != nil {
+			c.Logger.Error("database update by id error", slog.Any("error", err))
+			return nil, err
+		}
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionUpdate,
+			TargetType: auditevent_s.TargetTypeObjectFile,
+			TargetID:   of.ID,
+			TargetName: of.Name,
+			Before:     before,
+			After:      of,
+		}
+		return of, nil
+	}
+
+	res, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		c.Logger.Error("session failed error", slog.Any("error", err))
+		c.discardAcceptedUpload(ctx, os.TenantID, objectKey, info)
+		return nil, err
+	}
+	if err := c.AuditEvent.Record(ctx, audit); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	os = res.(*domain.ObjectFile)
 
 	// go func(org *domain.ObjectFile) {
 	// 	c.updateObjectFileNameForAllUsers(ctx, org)
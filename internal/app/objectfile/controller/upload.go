@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	a_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// uploadPartSize is the size of the parts streamed into the object storage;
+// at most one part per upload is held in memory.
+const uploadPartSize = 8 << 20
+
+// errUploadTooLarge is returned while streaming content which exceeds the
+// maximum upload size.
+var errUploadTooLarge = errors.New("upload exceeds the maximum size")
+
+// generateObjectKey function returns a new key for uploading content into the
+// object storage. The key ends with a unique identifier so two uploads never
+// overwrite each other; the original filename is only kept as metadata on the
+// object file record.
+func (c *ObjectFileControllerImpl) generateObjectKey(tenantID primitive.ObjectID, category uint64, subCategory uint64, classification uint64) string {
+	return fmt.Sprintf("ten_%v/cat_%d/subcat_%d/class_%d/%v", tenantID.Hex(), category, subCategory, classification, c.UUID.NewUUID())
+}
+
+// fileInspection holds the details computed from the content of an upload.
+type fileInspection struct {
+	Size     int64
+	SHA256   string
+	MimeType string
+}
+
+// contentInspector computes the size and the SHA-256 checksum of the content
+// and keeps its beginning for detecting its MIME type while it is streamed.
+// Reading fails once more than `maxSize` bytes were read.
+type contentInspector struct {
+	r       io.Reader
+	maxSize int64
+	size    int64
+	hasher  hash.Hash
+	header  []byte
+}
+
+func (ci *contentInspector) Read(p []byte) (int, error) {
+	// Keep failing as readers such as `io.ReadFull` drop the error of a read
+	// which filled their buffer.
+	if ci.tooLarge() {
+		return 0, errUploadTooLarge
+	}
+	n, err := ci.r.Read(p)
+	if n > 0 {
+		ci.hasher.Write(p[:n])
+		if missing := 512 - len(ci.header); missing > 0 {
+			ci.header = append(ci.header, p[:min(n, missing)]...)
+		}
+		ci.size += int64(n)
+		if ci.tooLarge() {
+			return n, errUploadTooLarge
+		}
+	}
+	return n, err
+}
+
+// tooLarge returns true if more than the maximum size was read.
+func (ci *contentInspector) tooLarge() bool {
+	return ci.maxSize > 0 && ci.size > ci.maxSize
+}
+
+// maxUploadSize returns the maximum size of an uploaded file in bytes.
+func (c *ObjectFileControllerImpl) maxUploadSize() int64 {
+	return int64(c.Config.AppServer.MaxUploadSizeInMB) << 20
+}
+
+// uploadStream function streams the file into the object storage and returns
+// the details computed from its content once the upload finished. The file is
+// never held in memory as a whole, as a consequence the upload cannot be
+// retried as a whole either; the object storage retries every part instead.
+func (c *ObjectFileControllerImpl) uploadStream(ctx context.Context, objectKey string, file io.Reader, filename string) (*fileInspection, error) {
+	ci := &contentInspector{
+		r:       file,
+		maxSize: c.maxUploadSize(),
+		hasher:  sha256.New(),
+	}
+	tooLargeErr := httperror.NewForSingleField(http.StatusRequestEntityTooLarge, "file", fmt.Sprintf("file must not be larger than %d MB", c.Config.AppServer.MaxUploadSizeInMB))
+	if err := c.ObjectStorage.UploadContentFromReader(ctx, objectKey, ci, uploadPartSize); err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			return nil, tooLargeErr
+		}
+		return nil, err
+	}
+	// Check the size again in case the object storage did not pass the error
+	// of the reader through.
+	if ci.tooLarge() {
+		c.discardUpload(ctx, objectKey)
+		return nil, tooLargeErr
+	}
+	return &fileInspection{
+		Size:     ci.size,
+		SHA256:   hex.EncodeToString(ci.hasher.Sum(nil)),
+		MimeType: detectMimeType(ci.header, filename),
+	}, nil
+}
+
+// discardUpload function deletes the content of an upload which was rejected
+// after it was streamed into the object storage.
+func (c *ObjectFileControllerImpl) discardUpload(ctx context.Context, objectKey string) {
+	if err := c.ObjectStorage.DeleteByKeys(context.WithoutCancel(ctx), []string{objectKey}); err != nil {
+		c.Logger.Warn("failed deleting rejected upload",
+			slog.String("object_key", objectKey),
+			slog.Any("error", err))
+	}
+}
+
+// discardAcceptedUpload function deletes the content of an accepted upload
+// which could not be saved to its record and removes it from the usage of the
+// tenant. Nothing is done if no content was uploaded.
+func (c *ObjectFileControllerImpl) discardAcceptedUpload(ctx context.Context, tenantID primitive.ObjectID, objectKey string, info *fileInspection) {
+	if info == nil {
+		return
+	}
+	c.discardUpload(ctx, objectKey)
+	c.releaseUsage(ctx, tenantID, info.Size, 0)
+}
+
+// acceptUpload function counts the streamed content against the storage quota
+// of the tenant and checks it does not duplicate another file of the tenant.
+// The duplicate is returned if duplicates are allowed.
+func (c *ObjectFileControllerImpl) acceptUpload(ctx context.Context, tenantID primitive.ObjectID, excludeID primitive.ObjectID, info *fileInspection, allowDuplicate bool) (*a_d.ObjectFile, error) {
+	if err := c.reserveUsage(ctx, tenantID, info.Size, 0); err != nil {
+		return nil, err
+	}
+	dup, err := c.findDuplicate(ctx, tenantID, excludeID, info.SHA256, allowDuplicate)
+	if err != nil {
+		c.releaseUsage(ctx, tenantID, info.Size, 0)
+		return nil, err
+	}
+	return dup, nil
+}
+
+// discardRecord function deletes the record of an object file whose upload
+// was rejected.
+func (c *ObjectFileControllerImpl) discardRecord(ctx context.Context, of *a_d.ObjectFile) {
+	if err := c.ObjectFileStorer.DeleteByID(context.WithoutCancel(ctx), of.ID); err != nil {
+		c.Logger.Error("database delete by id error",
+			slog.Any("object_file_id", of.ID),
+			slog.Any("error", err))
+		return
+	}
+	c.releaseUsage(ctx, of.TenantID, 0, 1)
+}
+
+// detectMimeType function returns the MIME type detected from the content. If
+// the content is only recognized as a generic type (for example office
+// documents are ZIP files) then the type of the filename extension is used.
+func detectMimeType(header []byte, filename string) string {
+	sniffed := http.DetectContentType(header)
+	switch sniffed {
+	case "application/octet-stream", "application/zip", "text/plain; charset=utf-8":
+		if byExt := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))); byExt != "" {
+			return byExt
+		}
+	}
+	return sniffed
+}
+
+// contentTypeFromMimeType function returns the content type of the object file
+// for the MIME type.
+func contentTypeFromMimeType(mimeType string) int8 {
+	if strings.HasPrefix(mimeType, "image/") {
+		return a_d.ContentTypeImage
+	}
+	return a_d.ContentTypeFile
+}
+
+// findDuplicate function returns an error if another uploaded object file of
+// the tenant has the same content, unless duplicates are allowed in which case
+// the duplicate is returned so it can be flagged on the record.
+func (c *ObjectFileControllerImpl) findDuplicate(ctx context.Context, tenantID primitive.ObjectID, excludeID primitive.ObjectID, checksum string, allowDuplicate bool) (*a_d.ObjectFile, error) {
+	dup, err := c.ObjectFileStorer.GetByTenantIDAndSHA256(ctx, tenantID, checksum)
+	if err != nil {
+		c.Logger.Error("database get by tenant id and sha256 error", slog.Any("error", err))
+		return nil, err
+	}
+	if dup == nil || dup.ID == excludeID {
+		return nil, nil
+	}
+	if !allowDuplicate {
+		c.Logger.Warn("duplicate object file upload rejected",
+			slog.Any("tenant_id", tenantID),
+			slog.Any("duplicate_of_object_file_id", dup.ID))
+		return nil, httperror.NewForSingleField(http.StatusConflict, "file", fmt.Sprintf("a file with the same content already exists: %s", dup.ID.Hex()))
+	}
+	return dup, nil
+}
+
+// requireUploaded function returns an error if the content of the object file
+// is not available in the object storage yet.
+func requireUploaded(of *a_d.ObjectFile) error {
+	switch of.Status {
+	case a_d.StatusPending:
+		return httperror.NewForSingleField(http.StatusConflict, "status", "object file upload is still pending")
+	case a_d.StatusError:
+		return httperror.NewForSingleField(http.StatusConflict, "status", "object file upload failed, please upload the file again")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// streamTestObjectStorage reads the whole stream like the object storage.
+type streamTestObjectStorage struct {
+	object_storage.ObjectStorager
+	received int64
+}
+
+func (s *streamTestObjectStorage) UploadContentFromReader(ctx context.Context, objectKey string, r io.Reader, partSize int64) error {
+	n, err := io.Copy(io.Discard, r)
+	s.received += n
+	return err
+}
+
+// swallowingTestObjectStorage reads the stream in a single `io.ReadFull` call
+// which drops the error of the read filling its buffer.
+type swallowingTestObjectStorage struct {
+	object_storage.ObjectStorager
+	size    int
+	deleted []string
+}
+
+func (s *swallowingTestObjectStorage) UploadContentFromReader(ctx context.Context, objectKey string, r io.Reader, partSize int64) error {
+	_, err := io.ReadFull(r, make([]byte, s.size))
+	return err
+}
+
+func (s *swallowingTestObjectStorage) DeleteByKeys(ctx context.Context, objectKeys []string) error {
+	s.deleted = append(s.deleted, objectKeys...)
+	return nil
+}
+
+func TestUploadStreamInspectsContent(t *testing.T) {
+	c, _ := newTenancyTestController(t, nil)
+	storage := &streamTestObjectStorage{}
+	c.ObjectStorage = storage
+	c.Config = &config.Conf{}
+	c.Config.AppServer.MaxUploadSizeInMB = 1
+
+	info, err := c.uploadStream(context.Background(), "key", strings.NewReader("hello world"), "hello.txt")
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if info.Size != 11 || storage.received != 11 {
+		t.Errorf("expected 11 bytes but received %d bytes uploaded as %d", storage.received, info.Size)
+	}
+	if info.SHA256 != "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" {
+		t.Errorf("unexpected checksum %s", info.SHA256)
+	}
+	if info.MimeType != "text/plain; charset=utf-8" {
+		t.Errorf("unexpected mime type %s", info.MimeType)
+	}
+}
+
+func TestUploadStreamRejectsFileOverMaximumSize(t *testing.T) {
+	c, _ := newTenancyTestController(t, nil)
+	c.ObjectStorage = &streamTestObjectStorage{}
+	c.Config = &config.Conf{}
+	c.Config.AppServer.MaxUploadSizeInMB = 1
+
+	_, err := c.uploadStream(context.Background(), "key", strings.NewReader(strings.Repeat("a", 1<<20+1)), "large.txt")
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected request entity too large error but received %v", err)
+	}
+}
+
+func TestUploadStreamDiscardsFileOverMaximumSizeWhenErrorIsDropped(t *testing.T) {
+	c, _ := newTenancyTestController(t, nil)
+	storage := &swallowingTestObjectStorage{size: 1<<20 + 1}
+	c.ObjectStorage = storage
+	c.Config = &config.Conf{}
+	c.Config.AppServer.MaxUploadSizeInMB = 1
+
+	_, err := c.uploadStream(context.Background(), "key", strings.NewReader(strings.Repeat("a", 1<<20+1)), "large.txt")
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected request entity too large error but received %v", err)
+	}
+	if len(storage.deleted) != 1 || storage.deleted[0] != "key" {
+		t.Errorf("expected the uploaded object to be deleted but deleted %v", storage.deleted)
+	}
+}
@@ -0,0 +1,493 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	uploadsession_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+const (
+	// uploadSessionIdleTimeout is how long an upload session is kept without
+	// receiving a chunk before it is considered abandoned.
+	uploadSessionIdleTimeout = 24 * time.Hour
+
+	// uploadSessionsCleanupBatchSize is the maximum number of abandoned
+	// upload sessions cleaned up per run.
+	uploadSessionsCleanupBatchSize = 100
+)
+
+type UploadSessionInitiateRequestIDO struct {
+	Name           string             `json:"name"`        // Optional
+	Description    string             `json:"description"` // Optional
+	FileName       string             `json:"file_name"`
+	FileType       string             `json:"file_type"`
+	SmartFolderID  primitive.ObjectID `json:"smart_folder_id"`
+	Classification uint64             `json:"classification"`
+	AllowDuplicate bool               `json:"allow_duplicate"` // Optional. If true, a file with the same content as an existing file is accepted but flagged.
+	Size           int64              `json:"size"`
+}
+
+// UploadSessionResponseIDO represents the upload session with the chunks
+// received so far so the client knows which chunks to upload when resuming.
+type UploadSessionResponseIDO struct {
+	*uploadsession_s.UploadSession
+	ReceivedChunks      []*uploadsession_s.UploadSessionChunk `json:"received_chunks"`
+	MissingChunkNumbers []int32                               `json:"missing_chunk_numbers"`
+}
+
+func newUploadSessionResponse(s *uploadsession_s.UploadSession) *UploadSessionResponseIDO {
+	res := &UploadSessionResponseIDO{
+		UploadSession:       s,
+		ReceivedChunks:      []*uploadsession_s.UploadSessionChunk{},
+		MissingChunkNumbers: []int32{},
+	}
+	for number := int32(1); number <= s.ChunkCount; number++ {
+		if chunk, ok := s.Chunks[strconv.Itoa(int(number))]; ok {
+			res.ReceivedChunks = append(res.ReceivedChunks, chunk)
+		} else {
+			res.MissingChunkNumbers = append(res.MissingChunkNumbers, number)
+		}
+	}
+	return res
+}
+
+func validateUploadSessionInitiateRequest(dirtyData *UploadSessionInitiateRequestIDO) error {
+	e := make(map[string]string)
+
+	if dirtyData.FileName == "" {
+		e["file_name"] = "missing value"
+	}
+	if dirtyData.SmartFolderID.IsZero() {
+		e["smart_folder_id"] = "missing value"
+	}
+	if dirtyData.Classification == 0 {
+		e["classification"] = "missing value"
+	}
+	if dirtyData.Size <= 0 {
+		e["size"] = "must be greater than zero"
+	}
+	if len(e) != 0 {
+		return httperror.NewForBadRequest(&e)
+	}
+	return nil
+}
+
+// uploadSessionChunkSize returns the size of the chunks of a file so the file
+// fits within the maximum number of parts of a multipart upload.
+func uploadSessionChunkSize(size int64) int64 {
+	chunkSize := int64(uploadPartSize)
+	if minimum := (size + object_storage.MaxPartCount - 1) / object_storage.MaxPartCount; minimum > chunkSize {
+		// Round up to the next mebibyte.
+		chunkSize = (minimum + (1 << 20) - 1) &^ ((1 << 20) - 1)
+	}
+	return chunkSize
+}
+
+// InitiateUploadSession function starts a resumable upload of the file. The
+// entire size of the file is counted against the storage quota of the tenant
+// until the upload session is completed, aborted or abandoned.
+func (c *ObjectFileControllerImpl) InitiateUploadSession(ctx context.Context, req *UploadSessionInitiateRequestIDO) (*UploadSessionResponseIDO, error) {
+	// Extract from our session the following data.
+	orgID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	orgName := ctx.Value(constants.SessionUserTenantName).(string)
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName := ctx.Value(constants.SessionUserName).(string)
+
+	if err := validateUploadSessionInitiateRequest(req); err != nil {
+		return nil, err
+	}
+	if req.Size > c.maxUploadSize() {
+		return nil, httperror.NewForSingleField(http.StatusRequestEntityTooLarge, "size", fmt.Sprintf("file must not be larger than %d MB", c.Config.AppServer.MaxUploadSizeInMB))
+	}
+
+	sf, err := c.getUploadableSmartFolder(ctx, req.SmartFolderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.reserveUsage(ctx, orgID, req.Size, 1); err != nil {
+		return nil, err
+	}
+
+	objectKey := c.generateObjectKey(orgID, sf.Category, sf.SubCategory, req.Classification)
+	uploadID, err := c.ObjectStorage.CreateMultipartUpload(ctx, objectKey)
+	if err != nil {
+		c.Logger.Error("failed creating multipart upload", slog.Any("error", err))
+		c.releaseUsage(ctx, orgID, req.Size, 1)
+		return nil, httperror.NewForSingleField(http.StatusBadGateway, "file", "failed starting upload, please try again")
+	}
+
+	chunkSize := uploadSessionChunkSize(req.Size)
+	now := time.Now()
+	s := &uploadsession_s.UploadSession{
+		ID:                primitive.NewObjectID(),
+		TenantID:          orgID,
+		TenantName:        orgName,
+		CreatedAt:         now,
+		CreatedByUserName: userName,
+		CreatedByUserID:   userID,
+		ModifiedAt:        now,
+		Status:            uploadsession_s.StatusActive,
+		Name:              req.Name,
+		Description:       req.Description,
+		FileName:          req.FileName,
+		FileType:          req.FileType,
+		SmartFolderID:     sf.ID,
+		Classification:    req.Classification,
+		AllowDuplicate:    req.AllowDuplicate,
+		Size:              req.Size,
+		ChunkSize:         chunkSize,
+		ChunkCount:        int32((req.Size + chunkSize - 1) / chunkSize),
+		Chunks:            map[string]*uploadsession_s.UploadSessionChunk{},
+		ObjectKey:         objectKey,
+		UploadID:          uploadID,
+		ExpiresAt:         now.Add(uploadSessionIdleTimeout),
+	}
+	if err := c.UploadSessionStorer.Create(ctx, s); err != nil {
+		c.Logger.Error("database create error", slog.Any("error", err))
+		c.abandonUploadSession(ctx, s)
+		return nil, err
+	}
+	return newUploadSessionResponse(s), nil
+}
+
+// getUploadSession function returns the upload session if it was initiated by
+// the authenticated user.
+func (c *ObjectFileControllerImpl) getUploadSession(ctx context.Context, id primitive.ObjectID) (*uploadsession_s.UploadSession, error) {
+	// Extract from our session the following data.
+	orgID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	s, err := c.UploadSessionStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if s == nil || s.TenantID != orgID || s.CreatedByUserID != userID {
+		return nil, httperror.NewForSingleField(http.StatusNotFound, "id", "upload session does not exist")
+	}
+	if s.Status == uploadsession_s.StatusActive && time.Now().After(s.ExpiresAt) {
+		return nil, httperror.NewForSingleField(http.StatusGone, "id", "upload session expired, please upload the file again")
+	}
+	return s, nil
+}
+
+// GetUploadSessionByID function returns the upload session with the chunks
+// received so far.
+func (c *ObjectFileControllerImpl) GetUploadSessionByID(ctx context.Context, id primitive.ObjectID) (*UploadSessionResponseIDO, error) {
+	s, err := c.getUploadSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newUploadSessionResponse(s), nil
+}
+
+// UploadChunk function uploads the numbered chunk of the upload session.
+// Chunks may be uploaded in any order and uploading a chunk again replaces it.
+func (c *ObjectFileControllerImpl) UploadChunk(ctx context.Context, id primitive.ObjectID, number int32, content io.Reader) (*UploadSessionResponseIDO, error) {
+	s, err := c.getUploadSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.Status != uploadsession_s.StatusActive || s.Assembled {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "upload session was already completed")
+	}
+	if number < 1 || number > s.ChunkCount {
+		return nil, httperror.NewForBadRequestWithSingleField("number", fmt.Sprintf("must be between 1 and %d", s.ChunkCount))
+	}
+
+	// Every chunk but the last must be exactly the chunk size.
+	expected := s.ChunkSize
+	if number == s.ChunkCount {
+		expected = s.Size - int64(s.ChunkCount-1)*s.ChunkSize
+	}
+	buf, err := io.ReadAll(io.LimitReader(content, expected+1))
+	if err != nil {
+		c.Logger.Warn("failed reading chunk", slog.Any("upload_session_id", s.ID), slog.Any("error", err))
+		return nil, httperror.NewForBadRequestWithSingleField("chunk", "failed receiving chunk, please try again")
+	}
+	if int64(len(buf)) != expected {
+		return nil, httperror.NewForBadRequestWithSingleField("chunk", fmt.Sprintf("chunk %d must be %d bytes", number, expected))
+	}
+
+	part, err := c.ObjectStorage.UploadPart(ctx, s.ObjectKey, s.UploadID, number, buf)
+	if err != nil {
+		c.Logger.Error("failed uploading part",
+			slog.Any("upload_session_id", s.ID),
+			slog.Any("number", number),
+			slog.Any("error", err))
+		return nil, httperror.NewForSingleField(http.StatusBadGateway, "chunk", "failed uploading chunk, please try again")
+	}
+
+	chunk := &uploadsession_s.UploadSessionChunk{
+		Number:     number,
+		Size:       part.Size,
+		ETag:       part.ETag,
+		UploadedAt: time.Now(),
+	}
+	expiresAt := chunk.UploadedAt.Add(uploadSessionIdleTimeout)
+	if err := c.UploadSessionStorer.SetChunk(ctx, s.ID, chunk, expiresAt); err != nil {
+		return nil, err
+	}
+
+	// Return the session as saved as other chunks may have been uploaded
+	// concurrently.
+	s, err = c.UploadSessionStorer.GetByID(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newUploadSessionResponse(s), nil
+}
+
+// CompleteUploadSession function assembles the chunks into the file and
+// creates the object file exactly as if the file was uploaded at once.
+// Completing an upload session again returns the object file created.
+func (c *ObjectFileControllerImpl) CompleteUploadSession(ctx context.Context, id primitive.ObjectID) (*domain.ObjectFile, error) {
+	s, err := c.getUploadSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.Status == uploadsession_s.StatusCompleted {
+		return c.ObjectFileStorer.GetByID(ctx, s.ObjectFileID)
+	}
+	if missing := newUploadSessionResponse(s).MissingChunkNumbers; len(missing) > 0 {
+		return nil, httperror.NewForBadRequestWithSingleField("chunks", fmt.Sprintf("missing chunks: %v", missing))
+	}
+
+	req := &ObjectFileCreateRequestIDO{
+		Name:           s.Name,
+		Description:    s.Description,
+		FileName:       s.FileName,
+		FileType:       s.FileType,
+		SmartFolderID:  s.SmartFolderID,
+		Classification: s.Classification,
+		AllowDuplicate: s.AllowDuplicate,
+	}
+	sf, err := c.getUploadableSmartFolder(ctx, req.SmartFolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the upload session so concurrent requests cannot complete it
+	// twice; the claim is released if the completion fails so it may be
+	// retried.
+	if err := c.claimUploadSession(ctx, s); err != nil {
+		return nil, err
+	}
+	res, err := c.completeClaimedUploadSession(ctx, s, req, sf)
+	if err != nil {
+		if _, rerr := c.UploadSessionStorer.UpdateStatusByID(context.WithoutCancel(ctx), s.ID, uploadsession_s.StatusClaimed, uploadsession_s.StatusActive); rerr != nil {
+			c.Logger.Error("failed releasing upload session",
+				slog.Any("upload_session_id", s.ID),
+				slog.Any("error", rerr))
+		}
+		return nil, err
+	}
+
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionCreate,
+		TargetType: auditevent_s.TargetTypeObjectFile,
+		TargetID:   res.ID,
+		TargetName: res.Name,
+		After:      res,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return res, nil
+}
+
+// claimUploadSession function marks the active upload session as claimed by
+// the request, else returns a `409 Conflict` error if another request is
+// already completing or aborting it.
+func (c *ObjectFileControllerImpl) claimUploadSession(ctx context.Context, s *uploadsession_s.UploadSession) error {
+	claimed, err := c.UploadSessionStorer.UpdateStatusByID(ctx, s.ID, uploadsession_s.StatusActive, uploadsession_s.StatusClaimed)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return httperror.NewForSingleField(http.StatusConflict, "id", "upload session is already being completed or aborted")
+	}
+	s.Status = uploadsession_s.StatusClaimed
+	return nil
+}
+
+// completeClaimedUploadSession function creates the object file of the
+// claimed upload session and marks the upload session as completed.
+func (c *ObjectFileControllerImpl) completeClaimedUploadSession(ctx context.Context, s *uploadsession_s.UploadSession, req *ObjectFileCreateRequestIDO, sf *smartfolder_s.SmartFolder) (*domain.ObjectFile, error) {
+	// Assemble the chunks, unless a previous attempt already did.
+	if !s.Assembled {
+		parts := make([]*object_storage.CompletedPart, 0, len(s.Chunks))
+		for _, chunk := range s.Chunks {
+			parts = append(parts, &object_storage.CompletedPart{PartNumber: chunk.Number, ETag: chunk.ETag, Size: chunk.Size})
+		}
+		sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+		if err := c.ObjectStorage.CompleteMultipartUpload(ctx, s.ObjectKey, s.UploadID, parts); err != nil {
+			c.Logger.Error("failed completing multipart upload",
+				slog.Any("upload_session_id", s.ID),
+				slog.Any("error", err))
+			return nil, httperror.NewForSingleField(http.StatusBadGateway, "file", "failed completing upload, please try again")
+		}
+		s.Assembled = true
+		s.ModifiedAt = time.Now()
+		if err := c.UploadSessionStorer.UpdateByID(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
+	// The chunks were received out of order so compute the details of the
+	// content from the assembled file.
+	info, err := c.inspectObject(ctx, s.ObjectKey, s.FileName)
+	if err != nil {
+		c.Logger.Error("failed inspecting assembled upload",
+			slog.Any("upload_session_id", s.ID),
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	// Reject or flag files which were already uploaded to the tenant.
+	dup, err := c.findDuplicate(ctx, s.TenantID, primitive.NilObjectID, info.SHA256, s.AllowDuplicate)
+	if err != nil {
+		c.abandonUploadSession(ctx, s)
+		return nil, err
+	}
+
+	res := newPendingObjectFile(ctx, req, sf, s.ObjectKey)
+	activateObjectFile(res, info)
+	res.UploadAttemptCount = 1
+	if dup != nil {
+		res.DuplicateOfObjectFileID = dup.ID
+	}
+
+	// Create the object file and mark the upload session as completed
+	// together so neither is saved without the other.
+	session, err := c.DbClient.StartSession()
+	if err != nil {
+		c.Logger.Error("start session error", slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if err := c.ObjectFileStorer.Create(sessCtx, res); err != nil {
+			c.Logger.Error("objectfile create error", slog.Any("error", err))
+			return nil, err
+		}
+		s.Status = uploadsession_s.StatusCompleted
+		s.ObjectFileID = res.ID
+		s.ModifiedAt = time.Now()
+		if err := c.UploadSessionStorer.UpdateByID(sessCtx, s); err != nil {
+			c.Logger.Error("failed marking upload session as completed", slog.Any("error", err))
+			return nil, err
+		}
+		return nil, nil
+	}
+	if _, err := session.WithTransaction(ctx, transactionFunc); err != nil {
+		c.Logger.Error("session failed error", slog.Any("error", err))
+		return nil, err
+	}
+
+	if info.Size != s.Size {
+		// The usage was reserved for the declared size.
+		c.releaseUsage(ctx, s.TenantID, s.Size-info.Size, 0)
+	}
+	return res, nil
+}
+
+// inspectObject function computes the details of the content of the object.
+func (c *ObjectFileControllerImpl) inspectObject(ctx context.Context, objectKey string, filename string) (*fileInspection, error) {
+	body, err := c.ObjectStorage.GetBinaryData(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	ci := &contentInspector{r: body, hasher: sha256.New()}
+	if _, err := io.Copy(io.Discard, ci); err != nil {
+		return nil, err
+	}
+	return &fileInspection{
+		Size:     ci.size,
+		SHA256:   hex.EncodeToString(ci.hasher.Sum(nil)),
+		MimeType: detectMimeType(ci.header, filename),
+	}, nil
+}
+
+// AbortUploadSession function discards the upload session and every chunk
+// uploaded so far.
+func (c *ObjectFileControllerImpl) AbortUploadSession(ctx context.Context, id primitive.ObjectID) error {
+	s, err := c.getUploadSession(ctx, id)
+	if err != nil {
+		return err
+	}
+	if s.Status == uploadsession_s.StatusCompleted {
+		return httperror.NewForBadRequestWithSingleField("id", "upload session was already completed")
+	}
+	if err := c.claimUploadSession(ctx, s); err != nil {
+		return err
+	}
+	return c.abandonUploadSession(ctx, s)
+}
+
+// CleanupAbandonedUploadSessions function discards the upload sessions of
+// every tenant which did not receive a chunk for too long, and forgets the
+// completed ones. Returns the number of upload sessions cleaned up.
+func (c *ObjectFileControllerImpl) CleanupAbandonedUploadSessions(ctx context.Context) (int, error) {
+	sessions, err := c.UploadSessionStorer.ListExpired(ctx, time.Now(), uploadSessionsCleanupBatchSize)
+	if err != nil {
+		c.Logger.Error("database list expired error", slog.Any("error", err))
+		return 0, err
+	}
+
+	cleaned := 0
+	for _, s := range sessions {
+		if s.Status == uploadsession_s.StatusCompleted {
+			err = c.UploadSessionStorer.DeleteByID(ctx, s.ID)
+		} else {
+			err = c.abandonUploadSession(ctx, s)
+		}
+		if err != nil {
+			return cleaned, err
+		}
+		cleaned++
+	}
+	return cleaned, nil
+}
+
+// abandonUploadSession function deletes the uploaded chunks, or the assembled
+// file, from the object storage, releases the usage reserved for the file and
+// deletes the upload session.
+func (c *ObjectFileControllerImpl) abandonUploadSession(ctx context.Context, s *uploadsession_s.UploadSession) error {
+	if s.Assembled {
+		c.discardUpload(ctx, s.ObjectKey)
+	} else if err := c.ObjectStorage.AbortMultipartUpload(context.WithoutCancel(ctx), s.ObjectKey, s.UploadID); err != nil {
+		c.Logger.Error("failed aborting multipart upload",
+			slog.Any("upload_session_id", s.ID),
+			slog.Any("error", err))
+		return err
+	}
+	c.releaseUsage(ctx, s.TenantID, s.Size, 1)
+
+	if err := c.UploadSessionStorer.DeleteByID(context.WithoutCancel(ctx), s.ID); err != nil {
+		c.Logger.Error("database delete by id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	uploadsession_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeUploadSessionStorer keeps a single upload session.
+type fakeUploadSessionStorer struct {
+	uploadsession_s.UploadSessionStorer
+	session *uploadsession_s.UploadSession
+}
+
+func (s *fakeUploadSessionStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*uploadsession_s.UploadSession, error) {
+	return s.session, nil
+}
+
+func (s *fakeUploadSessionStorer) SetChunk(ctx context.Context, id primitive.ObjectID, chunk *uploadsession_s.UploadSessionChunk, expiresAt time.Time) error {
+	s.session.Chunks[strconv.Itoa(int(chunk.Number))] = chunk
+	s.session.ExpiresAt = expiresAt
+	return nil
+}
+
+func (s *fakeUploadSessionStorer) UpdateStatusByID(ctx context.Context, id primitive.ObjectID, from int8, to int8) (bool, error) {
+	if s.session.Status != from {
+		return false, nil
+	}
+	s.session.Status = to
+	return true, nil
+}
+
+// chunkTestObjectStorage accepts every part.
+type chunkTestObjectStorage struct {
+	object_storage.ObjectStorager
+}
+
+func (s *chunkTestObjectStorage) UploadPart(ctx context.Context, objectKey string, uploadID string, partNumber int32, content []byte) (*object_storage.CompletedPart, error) {
+	return &object_storage.CompletedPart{PartNumber: partNumber, ETag: "1", Size: int64(len(content))}, nil
+}
+
+func TestUploadChunk(t *testing.T) {
+	owner := primitive.NewObjectID()
+	ctx := newTenancyTestContext(owner, user_d.UserRoleFrontlineStaff)
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	session := &uploadsession_s.UploadSession{
+		ID:              primitive.NewObjectID(),
+		TenantID:        owner,
+		CreatedByUserID: userID,
+		Status:          uploadsession_s.StatusActive,
+		Size:            uploadPartSize + 10,
+		ChunkSize:       uploadPartSize,
+		ChunkCount:      2,
+		Chunks:          map[string]*uploadsession_s.UploadSessionChunk{},
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+	c, _ := newTenancyTestController(t, nil)
+	c.ObjectStorage = &chunkTestObjectStorage{}
+	c.UploadSessionStorer = &fakeUploadSessionStorer{session: session}
+
+	// The last chunk holds the remaining bytes only.
+	_, err := c.UploadChunk(ctx, session.ID, 2, bytes.NewReader(make([]byte, 11)))
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+		t.Errorf("expected bad request error for a chunk of the wrong size but received %v", err)
+	}
+
+	res, err := c.UploadChunk(ctx, session.ID, 2, bytes.NewReader(make([]byte, 10)))
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if len(res.ReceivedChunks) != 1 || len(res.MissingChunkNumbers) != 1 || res.MissingChunkNumbers[0] != 1 {
+		t.Errorf("expected chunk 1 missing but received %+v and missing %v", res.ReceivedChunks, res.MissingChunkNumbers)
+	}
+
+	// Sessions are only available to the user who initiated them.
+	other := newTenancyTestContext(owner, user_d.UserRoleExecutive)
+	_, err = c.UploadChunk(other, session.ID, 1, bytes.NewReader(make([]byte, uploadPartSize)))
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusNotFound {
+		t.Errorf("expected not found error for another user but received %v", err)
+	}
+}
+
+func TestUploadSessionChunkSize(t *testing.T) {
+	if got := uploadSessionChunkSize(1 << 30); got != uploadPartSize {
+		t.Errorf("expected the default chunk size but received %d", got)
+	}
+	size := int64(object_storage.MaxPartCount)*uploadPartSize + 1
+	chunkSize := uploadSessionChunkSize(size)
+	if (size+chunkSize-1)/chunkSize > object_storage.MaxPartCount {
+		t.Errorf("chunk size %d exceeds the maximum number of parts", chunkSize)
+	}
+}
+
+func TestAbortUploadSessionClaimedByAnotherRequest(t *testing.T) {
+	owner := primitive.NewObjectID()
+	ctx := newTenancyTestContext(owner, user_d.UserRoleFrontlineStaff)
+	session := &uploadsession_s.UploadSession{
+		ID:              primitive.NewObjectID(),
+		TenantID:        owner,
+		CreatedByUserID: ctx.Value(constants.SessionUserID).(primitive.ObjectID),
+		Status:          uploadsession_s.StatusClaimed, // Being completed.
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+	c, _ := newTenancyTestController(t, nil)
+	c.ObjectStorage = &chunkTestObjectStorage{}
+	c.UploadSessionStorer = &fakeUploadSessionStorer{session: session}
+
+	err := c.AbortUploadSession(ctx, session.ID)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusConflict {
+		t.Errorf("expected conflict error but received %v", err)
+	}
+	if session.Status != uploadsession_s.StatusClaimed {
+		t.Errorf("expected the upload session to be left to the other request")
+	}
+}
@@ -4,10 +4,14 @@ const (
 	StatusActive   = 1
 	StatusError    = 2
 	StatusArchived = 3
+	StatusPending  = 4 // Record was saved but the upload has not finished yet.
 
 	ContentTypeFile  = 6
 	ContentTypeImage = 7
 
 	CategoryUnspecified      = 1
 	CategoryGovernmentCanada = 2
+
+	ObjectKeyLayoutFilename = 1 // Legacy layout where the key ends with the uploaded filename.
+	ObjectKeyLayoutUnique   = 2 // Layout where the key ends with a unique identifier.
 )
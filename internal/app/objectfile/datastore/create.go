@@ -19,6 +19,8 @@ func (impl ObjectFileStorerImpl) Create(ctx context.Context, u *ObjectFile) erro
 		impl.Logger.Warn("database insert objectfile not included id value, created id now.", slog.Any("id", u.ID))
 	}
 
+	u.StoredSize = u.ComputeStoredSize() // Keep the usage of the tenant computable.
+
 	_, err := impl.Collection.InsertOne(ctx, u)
 
 	// check for errors in the insertion
@@ -14,27 +14,121 @@ import (
 )
 
 type ObjectFile struct {
-	TenantID               primitive.ObjectID `bson:"tenant_id,omitempty" json:"tenant_id,omitempty"`
-	TenantName             string             `bson:"tenant_name" json:"tenant_name"`
-	ID                     primitive.ObjectID `bson:"_id" json:"id"`
-	CreatedAt              time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
-	CreatedByUserName      string             `bson:"created_by_user_name" json:"-"` // Hidden from public.
-	CreatedByUserID        primitive.ObjectID `bson:"created_by_user_id" json:"-"`   // Hidden from public.
-	ModifiedAt             time.Time          `bson:"modified_at,omitempty" json:"modified_at,omitempty"`
-	ModifiedByUserName     string             `bson:"modified_by_user_name" json:"-"` // Hidden from public.
-	ModifiedByUserID       primitive.ObjectID `bson:"modified_by_user_id" json:"-"`   // Hidden from public.
-	Name                   string             `bson:"name" json:"name"`
-	Description            string             `bson:"description" json:"description"`
-	Filename               string             `bson:"filename" json:"filename"`
-	ObjectKey              string             `bson:"object_key" json:"-"` // Hidden from public.
-	ObjectURL              string             `bson:"object_url" json:"-"` // Hidden from public.
-	Status                 int8               `bson:"status" json:"status"`
-	ContentType            int8               `bson:"content_type" json:"content_type"`
-	Classification         uint64             `bson:"classification" json:"classification"`
-	SmartFolderID          primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
-	SmartFolderName        string             `bson:"smart_folder_name" json:"smart_folder_name"`
-	SmartFolderCategory    uint64             `bson:"smart_folder_category,omitempty" json:"smart_folder_category,omitempty"`
-	SmartFolderSubCategory uint64             `bson:"smart_folder_sub_category,omitempty" json:"smart_folder_sub_category,omitempty"`
+	TenantID                primitive.ObjectID   `bson:"tenant_id,omitempty" json:"tenant_id,omitempty"`
+	TenantName              string               `bson:"tenant_name" json:"tenant_name"`
+	ID                      primitive.ObjectID   `bson:"_id" json:"id"`
+	CreatedAt               time.Time            `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	CreatedByUserName       string               `bson:"created_by_user_name" json:"-"` // Hidden from public.
+	CreatedByUserID         primitive.ObjectID   `bson:"created_by_user_id" json:"-"`   // Hidden from public.
+	ModifiedAt              time.Time            `bson:"modified_at,omitempty" json:"modified_at,omitempty"`
+	ModifiedByUserName      string               `bson:"modified_by_user_name" json:"-"` // Hidden from public.
+	ModifiedByUserID        primitive.ObjectID   `bson:"modified_by_user_id" json:"-"`   // Hidden from public.
+	Name                    string               `bson:"name" json:"name"`
+	Description             string               `bson:"description" json:"description"`
+	Filename                string               `bson:"filename" json:"filename"`
+	FileType                string               `bson:"file_type" json:"file_type"` // The MIME type declared by the uploader.
+	MimeType                string               `bson:"mime_type" json:"mime_type"` // The MIME type detected from the content.
+	Size                    int64                `bson:"size" json:"size"`
+	SHA256                  string               `bson:"sha256" json:"sha256"`
+	DuplicateOfObjectFileID primitive.ObjectID   `bson:"duplicate_of_object_file_id,omitempty" json:"duplicate_of_object_file_id,omitempty"`
+	ObjectKey               string               `bson:"object_key" json:"-"` // Hidden from public.
+	ObjectURL               string               `bson:"object_url" json:"-"` // Hidden from public.
+	Status                  int8                 `bson:"status" json:"status"`
+	ContentType             int8                 `bson:"content_type" json:"content_type"`
+	Classification          uint64               `bson:"classification" json:"classification"`
+	SmartFolderID           primitive.ObjectID   `bson:"smart_folder_id" json:"smart_folder_id"`
+	SmartFolderName         string               `bson:"smart_folder_name" json:"smart_folder_name"`
+	SmartFolderCategory     uint64               `bson:"smart_folder_category,omitempty" json:"smart_folder_category,omitempty"`
+	SmartFolderSubCategory  uint64               `bson:"smart_folder_sub_category,omitempty" json:"smart_folder_sub_category,omitempty"`
+	UploadAttemptCount      int                  `bson:"upload_attempt_count" json:"upload_attempt_count"`
+	UploadError             string               `bson:"upload_error,omitempty" json:"upload_error,omitempty"`
+	UploadedAt              time.Time            `bson:"uploaded_at,omitempty" json:"uploaded_at,omitempty"`
+	ObjectKeyLayout         int8                 `bson:"object_key_layout" json:"-"` // Hidden from public.
+	CurrentVersion          int                  `bson:"current_version" json:"current_version"`
+	Versions                []*ObjectFileVersion `bson:"versions" json:"-"` // Hidden from public, use the versions endpoint.
+
+	// StoredSize is the number of bytes the content of every version takes
+	// in the object storage and is counted against the quota of the tenant.
+	StoredSize int64 `bson:"stored_size" json:"stored_size"`
+
+	// DeletedAt is set when the object file was moved to the trash. The
+	// object file and its content are purged once `PurgeAt` passed.
+	DeletedAt         time.Time          `bson:"deleted_at" json:"deleted_at"`
+	DeletedByUserID   primitive.ObjectID `bson:"deleted_by_user_id" json:"deleted_by_user_id,omitempty"`
+	DeletedByUserName string             `bson:"deleted_by_user_name" json:"deleted_by_user_name,omitempty"`
+	PurgeAt           time.Time          `bson:"purge_at" json:"purge_at"`
+
+	// DeletedWithSmartFolder is true when the object file was moved to the
+	// trash with its smart folder and must be restored with it.
+	DeletedWithSmartFolder bool `bson:"deleted_with_smart_folder" json:"deleted_with_smart_folder,omitempty"`
+}
+
+// IsTrashed returns true if the object file was moved to the trash.
+func (of *ObjectFile) IsTrashed() bool {
+	return !of.DeletedAt.IsZero()
+}
+
+// ObjectFileVersion represents a previously or currently uploaded content of
+// the object file. Versions are never removed so the history is kept for
+// compliance purposes.
+type ObjectFileVersion struct {
+	Number              int                `bson:"number" json:"number"`
+	ObjectKey           string             `bson:"object_key" json:"-"` // Hidden from public.
+	Filename            string             `bson:"filename" json:"filename"`
+	MimeType            string             `bson:"mime_type" json:"mime_type"`
+	Size                int64              `bson:"size" json:"size"`
+	SHA256              string             `bson:"sha256" json:"sha256"`
+	UploadedByUserID    primitive.ObjectID `bson:"uploaded_by_user_id" json:"uploaded_by_user_id"`
+	UploadedByUserName  string             `bson:"uploaded_by_user_name" json:"uploaded_by_user_name"`
+	UploadedAt          time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+	RestoredFromVersion int                `bson:"restored_from_version,omitempty" json:"restored_from_version,omitempty"`
+}
+
+// GetVersion returns the version with the number or nil if it does not exist.
+func (of *ObjectFile) GetVersion(number int) *ObjectFileVersion {
+	for _, v := range of.Versions {
+		if v.Number == number {
+			return v
+		}
+	}
+	return nil
+}
+
+// AllObjectKeys returns the unique object keys used by the current content and
+// every version of the object file.
+func (of *ObjectFile) AllObjectKeys() []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	if of.ObjectKey != "" {
+		keys = append(keys, of.ObjectKey)
+		seen[of.ObjectKey] = true
+	}
+	for _, v := range of.Versions {
+		if v.ObjectKey != "" && !seen[v.ObjectKey] {
+			keys = append(keys, v.ObjectKey)
+			seen[v.ObjectKey] = true
+		}
+	}
+	return keys
+}
+
+// ComputeStoredSize returns the number of bytes the content of the object file
+// takes in the object storage. Restored versions reuse the object key of the
+// version they were restored from so they are not counted twice.
+func (of *ObjectFile) ComputeStoredSize() int64 {
+	if len(of.Versions) == 0 {
+		if of.Status == StatusError {
+			return 0
+		}
+		return of.Size
+	}
+	var size int64
+	for _, v := range of.Versions {
+		if v.RestoredFromVersion == 0 {
+			size += v.Size
+		}
+	}
+	return size
 }
 
 type ObjectFileListFilter struct {
@@ -50,6 +144,15 @@ type ObjectFileListFilter struct {
 	UserID          primitive.ObjectID
 	UserRole        int8
 	ExcludeArchived bool
+
+	// ExcludeSmartFolderIDs hides the object files of the smart folders which
+	// the authenticated user was not granted access to.
+	ExcludeSmartFolderIDs []primitive.ObjectID
+
+	// Trashed lists the object files in the trash instead of the others. The
+	// object files trashed with their smart folder are not listed as they are
+	// restored with it.
+	Trashed bool
 }
 
 type ObjectFileListResult struct {
@@ -68,12 +171,24 @@ type ObjectFileStorer interface {
 	Create(ctx context.Context, m *ObjectFile) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*ObjectFile, error)
 	UpdateByID(ctx context.Context, m *ObjectFile) error
+	UpdateStatusByID(ctx context.Context, id primitive.ObjectID, from int8, to int8) (bool, error)
 	ListByFilter(ctx context.Context, m *ObjectFileListFilter) (*ObjectFileListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *ObjectFileListFilter) ([]*ObjectFileAsSelectOption, error)
 	ListObjectKeysBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) ([]string, error)
 	ListBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) ([]*ObjectFile, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 	DeleteBySmartFolderID(ctx context.Context, smartFolderID primitive.ObjectID) error
+	ListByLegacyObjectKeyLayout(ctx context.Context, cursor primitive.ObjectID, limit int64) ([]*ObjectFile, error)
+	CountByObjectKey(ctx context.Context, objectKey string) (int64, error)
+	GetByTenantIDAndSHA256(ctx context.Context, tenantID primitive.ObjectID, sha256 string) (*ObjectFile, error)
+	TrashBySmartFolderID(ctx context.Context, sfid primitive.ObjectID, deletedAt time.Time, purgeAt time.Time, userID primitive.ObjectID, userName string) error
+	RestoreBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) error
+	ListIDsToPurge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error)
+	ListStalledUploads(ctx context.Context, before time.Time, limit int64) ([]*ObjectFile, error)
+	ListByTenantIDAfterID(ctx context.Context, tenantID primitive.ObjectID, cursor primitive.ObjectID, limit int64) ([]*ObjectFile, error)
+	ListUsageByTenantID(ctx context.Context, tenantID primitive.ObjectID) ([]*ObjectFileUsage, error)
+	GetUsageBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) (*ObjectFileUsage, error)
+	ListUsageGroupedByTenantID(ctx context.Context) ([]*ObjectFileUsage, error)
 	// //TODO: Add more...
 }
 
@@ -93,6 +208,8 @@ func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) Ob
 		{Keys: bson.D{{Key: "category", Value: -1}}},
 		{Keys: bson.D{{Key: "classification", Value: -1}}},
 		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "sha256", Value: 1}}},
+		{Keys: bson.D{{Key: "purge_at", Value: 1}}},
 		{Keys: bson.D{
 			{"tenant_name", "text"},
 			{"name", "text"},
@@ -24,3 +24,27 @@ func (impl ObjectFileStorerImpl) GetByID(ctx context.Context, id primitive.Objec
 	}
 	return &result, nil
 }
+
+// GetByTenantIDAndSHA256 function returns an uploaded, non-archived object
+// file of the tenant, outside of the trash, which has the same content
+// checksum or nil if none.
+func (impl ObjectFileStorerImpl) GetByTenantIDAndSHA256(ctx context.Context, tenantID primitive.ObjectID, sha256 string) (*ObjectFile, error) {
+	filter := bson.M{
+		"tenant_id":  tenantID,
+		"sha256":     sha256,
+		"status":     StatusActive,
+		"deleted_at": trashCondition(false),
+	}
+
+	var result ObjectFile
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by tenant id and sha256 error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func (impl ObjectFileStorerImpl) ListByFilter(ctx context.Context, f *ObjectFileListFilter) (*ObjectFileListResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Create the filter based on the cursor
@@ -25,12 +25,13 @@ func (impl ObjectFileStorerImpl) ListByFilter(ctx context.Context, f *ObjectFile
 	if f.TenantID != primitive.NilObjectID {
 		filter["tenant_id"] = f.TenantID
 	}
-	if !f.SmartFolderID.IsZero() {
-		filter["smart_folder_id"] = f.SmartFolderID
+	if sf := smartFolderFilter(f); sf != nil {
+		filter["smart_folder_id"] = sf
 	}
 	if f.ExcludeArchived {
 		filter["status"] = bson.M{"$ne": StatusArchived} // Do not list archived items! This code
 	}
+	addTrashConditions(filter, f.Trashed)
 
 	impl.Logger.Debug("fetching objectfiles list",
 		slog.Any("Cursor", f.Cursor),
@@ -40,6 +41,7 @@ func (impl ObjectFileStorerImpl) ListByFilter(ctx context.Context, f *ObjectFile
 		slog.Any("TenantID", f.TenantID),
 		slog.Any("smart_folder_id", f.SmartFolderID),
 		slog.Any("ExcludeArchived", f.ExcludeArchived),
+		slog.Int("ExcludeSmartFolderIDs", len(f.ExcludeSmartFolderIDs)),
 	)
 
 	// Include additional filters for our cursor-based pagination pertaining to sorting and limit.
@@ -93,7 +95,7 @@ func (impl ObjectFileStorerImpl) ListByFilter(ctx context.Context, f *ObjectFile
 }
 
 func (impl ObjectFileStorerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *ObjectFileListFilter) ([]*ObjectFileAsSelectOption, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Get a reference to the collection
@@ -117,6 +119,12 @@ func (impl ObjectFileStorerImpl) ListAsSelectOptionByFilter(ctx context.Context,
 	if f.UserID != primitive.NilObjectID {
 		query["user_id"] = f.UserID
 	}
+	if f.TenantID != primitive.NilObjectID {
+		query["tenant_id"] = f.TenantID
+	}
+	if sf := smartFolderFilter(f); sf != nil {
+		query["smart_folder_id"] = sf
+	}
 
 	if startAfter != "" {
 		// Find the document with the given startAfter ID
@@ -131,6 +139,7 @@ func (impl ObjectFileStorerImpl) ListAsSelectOptionByFilter(ctx context.Context,
 	if f.ExcludeArchived {
 		query["status"] = bson.M{"$ne": StatusArchived} // Do not list archived items! This code
 	}
+	addTrashConditions(query, f.Trashed)
 
 	options.SetSort(bson.D{{sortField, 1}}) // Sort in ascending order based on the specified field
 
@@ -148,3 +157,19 @@ func (impl ObjectFileStorerImpl) ListAsSelectOptionByFilter(ctx context.Context,
 
 	return results, nil
 }
+
+// smartFolderFilter returns the condition to apply on the `smart_folder_id`
+// field or nil if the list is not restricted by smart folders.
+func smartFolderFilter(f *ObjectFileListFilter) interface{} {
+	if len(f.ExcludeSmartFolderIDs) == 0 {
+		if f.SmartFolderID.IsZero() {
+			return nil
+		}
+		return f.SmartFolderID
+	}
+	cond := bson.M{"$nin": f.ExcludeSmartFolderIDs}
+	if !f.SmartFolderID.IsZero() {
+		cond["$eq"] = f.SmartFolderID
+	}
+	return cond
+}
@@ -10,15 +10,15 @@ import (
 )
 
 func (impl ObjectFileStorerImpl) ListObjectKeysBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Create the filter based on the cursor
 	filter := bson.M{}
 	filter["smart_folder_id"] = sfid
 
-	// Define projection to include only the object_key field
-	projection := bson.M{"object_key": 1}
+	// Define projection to include only the object key fields
+	projection := bson.M{"object_key": 1, "versions.object_key": 1}
 
 	// Find documents matching the filter and projection
 	cursor, err := impl.Collection.Find(ctx, filter, options.Find().SetProjection(projection))
@@ -34,7 +34,7 @@ func (impl ObjectFileStorerImpl) ListObjectKeysBySmartFolderID(ctx context.Conte
 		if err := cursor.Decode(&obj); err != nil {
 			return nil, err
 		}
-		objectKeys = append(objectKeys, obj.ObjectKey)
+		objectKeys = append(objectKeys, obj.AllObjectKeys()...)
 	}
 
 	// Check for any errors during cursor iteration
@@ -47,11 +47,11 @@ func (impl ObjectFileStorerImpl) ListObjectKeysBySmartFolderID(ctx context.Conte
 }
 
 func (impl ObjectFileStorerImpl) ListBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) ([]*ObjectFile, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Create the filter based on the smart_folder_id
-	filter := bson.M{"smart_folder_id": sfid}
+	filter := bson.M{"smart_folder_id": sfid, "deleted_at": trashCondition(false)}
 
 	// Find documents matching the filter
 	cursor, err := impl.Collection.Find(ctx, filter)
@@ -78,3 +78,101 @@ func (impl ObjectFileStorerImpl) ListBySmartFolderID(ctx context.Context, sfid p
 	// Return the list of ObjectFile structs
 	return objectFiles, nil
 }
+
+// ListByLegacyObjectKeyLayout function returns the object files, ordered by
+// id, whose object keys were not generated with the unique key layout. Files
+// whose upload never finished are excluded as they have no content to move.
+func (impl ObjectFileStorerImpl) ListByLegacyObjectKeyLayout(ctx context.Context, cursor primitive.ObjectID, limit int64) ([]*ObjectFile, error) {
+	filter := bson.M{
+		"object_key_layout": bson.M{"$ne": ObjectKeyLayoutUnique},
+		"status":            bson.M{"$in": []int8{StatusActive, StatusArchived}},
+	}
+	if !cursor.IsZero() {
+		filter["_id"] = bson.M{"$gt": cursor}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cur, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var objectFiles []*ObjectFile
+	if err := cur.All(ctx, &objectFiles); err != nil {
+		return nil, err
+	}
+	return objectFiles, nil
+}
+
+// CountByObjectKey function returns how many object files reference the key
+// either as their current content or in their version history.
+func (impl ObjectFileStorerImpl) CountByObjectKey(ctx context.Context, objectKey string) (int64, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"object_key": objectKey},
+			{"versions.object_key": objectKey},
+		},
+	}
+	return impl.Collection.CountDocuments(ctx, filter)
+}
+
+// ListStalledUploads function returns the object files, of every tenant, whose
+// upload is still pending although the record was last modified before the
+// time.
+func (impl ObjectFileStorerImpl) ListStalledUploads(ctx context.Context, before time.Time, limit int64) ([]*ObjectFile, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":      StatusPending,
+		"modified_at": bson.M{"$lte": before},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cur, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var objectFiles []*ObjectFile
+	if err := cur.All(ctx, &objectFiles); err != nil {
+		return nil, err
+	}
+	return objectFiles, nil
+}
+
+// ListByTenantIDAfterID function returns the object files of the tenant,
+// including the trashed ones, ordered by their ID and starting after the
+// cursor. Used for walking through every object file of the tenant in batches.
+func (impl ObjectFileStorerImpl) ListByTenantIDAfterID(ctx context.Context, tenantID primitive.ObjectID, cursor primitive.ObjectID, limit int64) ([]*ObjectFile, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{"tenant_id": tenantID}
+	if !cursor.IsZero() {
+		filter["_id"] = bson.M{"$gt": cursor}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cur, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var objectFiles []*ObjectFile
+	if err := cur.All(ctx, &objectFiles); err != nil {
+		return nil, err
+	}
+	return objectFiles, nil
+}
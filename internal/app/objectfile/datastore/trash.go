@@ -0,0 +1,102 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// trashCondition returns the condition to apply on the `deleted_at` field to
+// match either the trashed object files or the others. Records created
+// before the trash existed do not have the field.
+func trashCondition(trashed bool) bson.M {
+	if trashed {
+		return bson.M{"$gt": time.Time{}}
+	}
+	return bson.M{"$in": bson.A{nil, time.Time{}}}
+}
+
+// addTrashConditions adds to the list filter the conditions to list either
+// the object files in the trash or the others.
+func addTrashConditions(filter bson.M, trashed bool) {
+	filter["deleted_at"] = trashCondition(trashed)
+	if trashed {
+		filter["deleted_with_smart_folder"] = bson.M{"$ne": true}
+	}
+}
+
+// TrashBySmartFolderID function moves to the trash every object file of the
+// smart folder which is not already in the trash.
+func (impl ObjectFileStorerImpl) TrashBySmartFolderID(ctx context.Context, sfid primitive.ObjectID, deletedAt time.Time, purgeAt time.Time, userID primitive.ObjectID, userName string) error {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"smart_folder_id": sfid,
+		"deleted_at":      trashCondition(false),
+	}
+	update := bson.M{"$set": bson.M{
+		"deleted_at":                deletedAt,
+		"deleted_by_user_id":        userID,
+		"deleted_by_user_name":      userName,
+		"purge_at":                  purgeAt,
+		"deleted_with_smart_folder": true,
+	}}
+	_, err := impl.Collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// RestoreBySmartFolderID function takes out of the trash every object file
+// which was trashed with the smart folder.
+func (impl ObjectFileStorerImpl) RestoreBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"smart_folder_id":           sfid,
+		"deleted_with_smart_folder": true,
+	}
+	update := bson.M{"$set": bson.M{
+		"deleted_at":                time.Time{},
+		"deleted_by_user_id":        primitive.NilObjectID,
+		"deleted_by_user_name":      "",
+		"purge_at":                  time.Time{},
+		"deleted_with_smart_folder": false,
+	}}
+	_, err := impl.Collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// ListIDsToPurge returns the ID of every trashed object file, of every
+// tenant, whose retention window ended before the time.
+func (impl ObjectFileStorerImpl) ListIDsToPurge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"deleted_at": trashCondition(true),
+		"purge_at":   bson.M{"$lte": before},
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
@@ -3,12 +3,15 @@ package datastore
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (impl ObjectFileStorerImpl) UpdateByID(ctx context.Context, m *ObjectFile) error {
 	filter := bson.D{{"_id", m.ID}}
+	m.StoredSize = m.ComputeStoredSize() // Keep the usage of the tenant computable.
 
 	update := bson.M{ // DEVELOPERS NOTE: https://stackoverflow.com/a/60946010
 		"$set": m,
@@ -22,3 +25,22 @@ func (impl ObjectFileStorerImpl) UpdateByID(ctx context.Context, m *ObjectFile)
 
 	return nil
 }
+
+// UpdateStatusByID function changes the status of the object file only if it
+// still has the `from` status. Returns false if the status was changed by
+// someone else.
+func (impl ObjectFileStorerImpl) UpdateStatusByID(ctx context.Context, id primitive.ObjectID, from int8, to int8) (bool, error) {
+	filter := bson.M{"_id": id, "status": from}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      to,
+			"modified_at": time.Now(),
+		},
+	}
+	res, err := impl.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update status by id error", slog.Any("error", err))
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
@@ -0,0 +1,108 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ObjectFileUsage represents the storage used by a group of object files.
+// Which fields are set depends on how the object files were grouped.
+type ObjectFileUsage struct {
+	TenantID        primitive.ObjectID `bson:"tenant_id" json:"tenant_id,omitempty"`
+	SmartFolderID   primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id,omitempty"`
+	SmartFolderName string             `bson:"smart_folder_name" json:"smart_folder_name,omitempty"`
+	Classification  uint64             `bson:"classification" json:"classification,omitempty"`
+	UsedInBytes     int64              `bson:"used_in_bytes" json:"used_in_bytes"`
+	FileCount       int64              `bson:"file_count" json:"file_count"`
+}
+
+// storedSizeExpression returns the stored size of the object file. Records
+// saved before the stored size was tracked fall back to their size.
+var storedSizeExpression = bson.M{"$ifNull": bson.A{"$stored_size", "$size"}}
+
+// ListUsageByTenantID function returns the storage used by the object files,
+// including the trashed ones, of the tenant grouped by smart folder and
+// classification.
+func (impl ObjectFileStorerImpl) ListUsageByTenantID(ctx context.Context, tenantID primitive.ObjectID) ([]*ObjectFileUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tenant_id": tenantID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"smart_folder_id": "$smart_folder_id",
+				"classification":  "$classification",
+			},
+			"smart_folder_name": bson.M{"$last": "$smart_folder_name"},
+			"used_in_bytes":     bson.M{"$sum": storedSizeExpression},
+			"file_count":        bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":               0,
+			"tenant_id":         tenantID,
+			"smart_folder_id":   "$_id.smart_folder_id",
+			"classification":    "$_id.classification",
+			"smart_folder_name": 1,
+			"used_in_bytes":     1,
+			"file_count":        1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "smart_folder_name", Value: 1}, {Key: "classification", Value: 1}}}},
+	}
+	return impl.aggregateUsage(ctx, pipeline)
+}
+
+// GetUsageBySmartFolderID function returns the storage used by the object
+// files, including the trashed ones, of the smart folder.
+func (impl ObjectFileStorerImpl) GetUsageBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) (*ObjectFileUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"smart_folder_id": sfid}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$smart_folder_id",
+			"tenant_id":       bson.M{"$first": "$tenant_id"},
+			"smart_folder_id": bson.M{"$first": "$smart_folder_id"},
+			"used_in_bytes":   bson.M{"$sum": storedSizeExpression},
+			"file_count":      bson.M{"$sum": 1},
+		}}},
+	}
+	usages, err := impl.aggregateUsage(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if len(usages) == 0 {
+		return &ObjectFileUsage{SmartFolderID: sfid}, nil
+	}
+	return usages[0], nil
+}
+
+// ListUsageGroupedByTenantID function returns the storage used by the object
+// files, including the trashed ones, of every tenant which has object files.
+func (impl ObjectFileStorerImpl) ListUsageGroupedByTenantID(ctx context.Context) ([]*ObjectFileUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$tenant_id",
+			"tenant_id":     bson.M{"$first": "$tenant_id"},
+			"used_in_bytes": bson.M{"$sum": storedSizeExpression},
+			"file_count":    bson.M{"$sum": 1},
+		}}},
+	}
+	return impl.aggregateUsage(ctx, pipeline)
+}
+
+func (impl ObjectFileStorerImpl) aggregateUsage(ctx context.Context, pipeline mongo.Pipeline) ([]*ObjectFileUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cur, err := impl.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var usages []*ObjectFileUsage
+	if err := cur.All(ctx, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
@@ -14,34 +14,29 @@ import (
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
+// unmarshalCreateRequest function reads the form fields of the request and
+// leaves the file unread so the controller streams it into the object storage.
+// The `file` field must be the last field of the form.
 func (h *Handler) unmarshalCreateRequest(ctx context.Context, r *http.Request) (*a_c.ObjectFileCreateRequestIDO, error) {
-	defer r.Body.Close()
-
-	// Parse the multipart form data
-	err := r.ParseMultipartForm(32 << 20) // Limit the maximum memory used for parsing to 32MB
+	// Read the form fields preceding the file.
+	values, file, err := readMultipartUntilFile(r, "smart_folder_id")
 	if err != nil {
-		h.Logger.Error("failed parsing multipart form", slog.Any("error", err))
+		h.Logger.Error("failed reading multipart form", slog.Any("error", err))
 		return nil, err
 	}
 
 	// Get the values of form fields
-	name := r.FormValue("name")
-	description := r.FormValue("description")
-	smartFolderIDStr := r.FormValue("smart_folder_id")
-	classificationStr := r.FormValue("classification")
+	name := values["name"]
+	description := values["description"]
+	smartFolderIDStr := values["smart_folder_id"]
+	classificationStr := values["classification"]
 	classification, _ := strconv.ParseInt(classificationStr, 10, 64)
-
-	// Get the uploaded file from the request
-	file, header, err := r.FormFile("file")
-	if err != nil {
-		h.Logger.Error("failed unmarshalling form file", slog.Any("error", err))
-		return nil, err
-	}
+	allowDuplicate, _ := strconv.ParseBool(values["allow_duplicate"])
 
 	sfid, err := primitive.ObjectIDFromHex(smartFolderIDStr)
 	if err != nil {
 		h.Logger.Error("failed parsing primitive", slog.Any("error", err))
-		return nil, err
+		return nil, httperror.NewForBadRequestWithSingleField("smart_folder_id", "invalid value")
 	}
 
 	// Initialize our array which will store all the results from the remote server.
@@ -50,12 +45,13 @@ func (h *Handler) unmarshalCreateRequest(ctx context.Context, r *http.Request) (
 		Description:    description,
 		SmartFolderID:  sfid,
 		Classification: uint64(classification),
+		AllowDuplicate: allowDuplicate,
 	}
 
-	if header != nil {
+	if file != nil {
 		// Extract filename and filetype from the file header
-		requestData.FileName = header.Filename
-		requestData.FileType = header.Header.Get("Content-Type")
+		requestData.FileName = file.FileName()
+		requestData.FileType = file.Header.Get("Content-Type")
 		requestData.File = file
 	}
 	return requestData, nil
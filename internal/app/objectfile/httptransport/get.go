@@ -2,8 +2,8 @@ package httptransport
 
 import (
 	"encoding/json"
-	"errors"
-	"log"
+	"io"
+	"log/slog"
 	"net/http"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -68,29 +68,24 @@ func (h *Handler) GetContentByID(w http.ResponseWriter, r *http.Request, id stri
 
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		httperror.ResponseError(w, errors.New("invalid object ID")) // Generic error message
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid value"))
 		return
 	}
 
-	// Retrieve the file data from the object storage.
-	content, filename, contentType, err := h.Controller.GetContent(ctx, objectID)
+	res, err := h.Controller.GetContent(ctx, objectID)
 	if err != nil {
-		log.Println("Error retrieving file content:", err)                // Log the error
-		httperror.ResponseError(w, errors.New("failed to retrieve file")) // Generic error message
+		httperror.ResponseError(w, err)
 		return
 	}
+	defer res.Content.Close()
 
 	// Set content headers for file download.
-	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
-	w.Header().Set("Content-Type", contentType)
-
-	// Optionally, set the Content-Length header if known.
-	// w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Header().Set("Content-Disposition", "attachment; filename="+res.Filename)
+	w.Header().Set("Content-Type", res.ContentType)
 
-	// Write the file content to the response body.
-	if _, err := w.Write(content); err != nil {
-		log.Println("Error writing file content to response:", err)                        // Log the error
-		httperror.ResponseError(w, errors.New("failed to write file content to response")) // Generic error message
+	// Stream the file content to the response body.
+	if _, err := io.Copy(w, res.Content); err != nil {
+		h.Logger.Error("failed streaming file content to response", slog.Any("error", err))
 		return
 	}
 }
@@ -0,0 +1,40 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) MigrateObjectKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cursor := primitive.NilObjectID
+	if c := r.URL.Query().Get("cursor"); c != "" {
+		var err error
+		cursor, err = primitive.ObjectIDFromHex(c)
+		if err != nil {
+			httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("cursor", "invalid value"))
+			return
+		}
+	}
+
+	res, err := h.Controller.MigrateObjectKeys(ctx, cursor)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalObjectKeyMigrationResponse(res, w)
+}
+
+func MarshalObjectKeyMigrationResponse(res *objectfile_c.ObjectKeyMigrationResponseIDO, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
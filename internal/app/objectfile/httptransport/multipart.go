@@ -0,0 +1,57 @@
+package httptransport
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// maxFormValueSize is the maximum size of a form field other than the file.
+const maxFormValueSize = 64 << 10
+
+// readMultipartUntilFile function reads the form fields of the multipart
+// request until it reaches the `file` part which is returned unread so it can
+// be streamed into the object storage. The `file` part must be sent last as
+// every field after it is ignored; a `400 Bad Request` error is returned if
+// one of the `required` fields was not sent before it. The returned part is
+// nil if the request has no file.
+func readMultipartUntilFile(r *http.Request, required ...string) (map[string]string, *multipart.Part, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, httperror.NewForBadRequestWithSingleField("file", "request must be multipart form data")
+	}
+
+	values := map[string]string{}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return values, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if part.FormName() == "file" {
+			for _, name := range required {
+				if _, ok := values[name]; !ok {
+					return nil, nil, httperror.NewForBadRequestWithSingleField("file", fmt.Sprintf("form field %s must be sent before the file", name))
+				}
+			}
+			if part.FileName() == "" {
+				return values, nil, nil
+			}
+			return values, part, nil
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, maxFormValueSize+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(value) > maxFormValueSize {
+			return nil, nil, httperror.NewForBadRequestWithSingleField(part.FormName(), "value is too long")
+		}
+		values[part.FormName()] = string(value)
+	}
+}
@@ -0,0 +1,50 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func UnmarshalReconcileStorageRequest(r *http.Request) (*objectfile_c.StorageReconciliationRequestIDO, error) {
+	var requestData objectfile_c.StorageReconciliationRequestIDO
+
+	defer r.Body.Close()
+
+	// An empty payload only requests the report.
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+
+	return &requestData, nil
+}
+
+func (h *Handler) ReconcileStorage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := UnmarshalReconcileStorageRequest(r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.ReconcileStorage(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalStorageReconciliationResponse(res, w)
+}
+
+func MarshalStorageReconciliationResponse(res *objectfile_c.StorageReconciliationResponseIDO, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
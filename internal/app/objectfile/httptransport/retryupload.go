@@ -0,0 +1,50 @@
+package httptransport
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	a_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// RetryUploadByID uploads the file again for an object file whose upload
+// failed. The `file` field must be the last field of the form.
+func (h *Handler) RetryUploadByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	values, file, err := readMultipartUntilFile(r)
+	if err != nil {
+		h.Logger.Error("failed reading multipart form", slog.Any("error", err))
+		httperror.ResponseError(w, err)
+		return
+	}
+	allowDuplicate, _ := strconv.ParseBool(values["allow_duplicate"])
+
+	req := &a_c.ObjectFileRetryUploadRequestIDO{
+		ID:             objectID,
+		AllowDuplicate: allowDuplicate,
+	}
+	if file != nil {
+		req.FileName = file.FileName()
+		req.FileType = file.Header.Get("Content-Type")
+		req.File = file
+	}
+
+	res, err := h.Controller.RetryUploadByID(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDetailResponse(res, w)
+}
@@ -0,0 +1,96 @@
+package httptransport
+
+import (
+	"net/http"
+	"strconv"
+
+	sub_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f := &sub_s.ObjectFileListFilter{
+		Cursor:        primitive.NilObjectID,
+		SmartFolderID: primitive.NilObjectID,
+		PageSize:      25,
+		SortField:     "_id",
+		SortOrder:     1, // 1=ascending | -1=descending
+	}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	cursor := query.Get("cursor")
+	if cursor != "" {
+		cursor, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			httperror.ResponseError(w, err)
+			return
+		}
+		f.Cursor = cursor
+	}
+
+	sfidstr := query.Get("smart_folder_id")
+	if sfidstr != "" {
+		smartFolderID, err := primitive.ObjectIDFromHex(sfidstr)
+		if err != nil {
+			httperror.ResponseError(w, err)
+			return
+		}
+		f.SmartFolderID = smartFolderID
+	}
+
+	pageSize := query.Get("page_size")
+	if pageSize != "" {
+		pageSize, _ := strconv.ParseInt(pageSize, 10, 64)
+		if pageSize == 0 || pageSize > 250 {
+			pageSize = 250
+		}
+		f.PageSize = pageSize
+	}
+
+	m, err := h.Controller.ListTrashByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func (h *Handler) RestoreByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.RestoreByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDetailResponse(res, w)
+}
+
+func (h *Handler) PurgeByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.PurgeByID(ctx, objectID); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -14,54 +14,60 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// UnmarshalUpdateRequest function reads the form fields of the request and
+// leaves the file, if any, unread so the controller streams it into the object
+// storage. The `file` field must be the last field of the form.
 func UnmarshalUpdateRequest(ctx context.Context, r *http.Request) (*sub_c.ObjectFileUpdateRequestIDO, error) {
-	defer r.Body.Close()
-
-	// Parse the multipart form data
-	err := r.ParseMultipartForm(32 << 20) // Limit the maximum memory used for parsing to 32MB
+	// Read the form fields preceding the file.
+	values, file, err := readMultipartUntilFile(r, "id")
 	if err != nil {
-		log.Println("UnmarshalUpdateRequest:ParseMultipartForm:err:", err)
+		log.Println("UnmarshalUpdateRequest:readMultipartUntilFile:err:", err)
 		return nil, err
 	}
 
 	// Get the values of form fields
-	id := r.FormValue("id")
-	name := r.FormValue("name")
-	description := r.FormValue("description")
-	ownershipID := r.FormValue("ownership_id")
-	ownershipTypeStr := r.FormValue("ownership_type")
+	id := values["id"]
+	name := values["name"]
+	description := values["description"]
+	ownershipID := values["ownership_id"]
+	ownershipTypeStr := values["ownership_type"]
 	ownershipType, _ := strconv.ParseInt(ownershipTypeStr, 10, 64)
+	smartFolderIDStr := values["smart_folder_id"]
+	classificationStr := values["classification"]
+	classification, _ := strconv.ParseInt(classificationStr, 10, 64)
+	allowDuplicate, _ := strconv.ParseBool(values["allow_duplicate"])
 
-	// Get the uploaded file from the request
-	file, header, err := r.FormFile("file")
+	oid, err := primitive.ObjectIDFromHex(ownershipID)
 	if err != nil {
-		log.Println("UnmarshalUpdateRequest:FormFile:err:", err)
-		// return nil, err, http.StatusInternalServerError
+		log.Println("UnmarshalUpdateRequest: primitive.ObjectIDFromHex:err:", err)
 	}
 
-	oid, err := primitive.ObjectIDFromHex(ownershipID)
+	aid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		log.Println("UnmarshalUpdateRequest: primitive.ObjectIDFromHex:err:", err)
 	}
 
-	aid, err := primitive.ObjectIDFromHex(id)
+	sfid, err := primitive.ObjectIDFromHex(smartFolderIDStr)
 	if err != nil {
 		log.Println("UnmarshalUpdateRequest: primitive.ObjectIDFromHex:err:", err)
 	}
 
 	// Initialize our array which will store all the results from the remote server.
 	requestData := &a_c.ObjectFileUpdateRequestIDO{
-		ID:            aid,
-		Name:          name,
-		Description:   description,
-		OwnershipID:   oid,
-		OwnershipType: int8(ownershipType),
+		ID:             aid,
+		Name:           name,
+		Description:    description,
+		OwnershipID:    oid,
+		OwnershipType:  int8(ownershipType),
+		SmartFolderID:  sfid,
+		Classification: uint64(classification),
+		AllowDuplicate: allowDuplicate,
 	}
 
-	if header != nil {
+	if file != nil {
 		// Extract filename and filetype from the file header
-		requestData.FileName = header.Filename
-		requestData.FileType = header.Header.Get("Content-Type")
+		requestData.FileName = file.FileName()
+		requestData.FileType = file.Header.Get("Content-Type")
 		requestData.File = file
 	}
 	return requestData, nil
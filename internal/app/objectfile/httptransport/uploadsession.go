@@ -0,0 +1,128 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func UnmarshalInitiateUploadSessionRequest(r *http.Request) (*objectfile_c.UploadSessionInitiateRequestIDO, error) {
+	var requestData objectfile_c.UploadSessionInitiateRequestIDO
+
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		return nil, httperror.NewForSingleField(http.StatusBadRequest, "non_field_error", "payload structure is wrong")
+	}
+	return &requestData, nil
+}
+
+func (h *Handler) InitiateUploadSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := UnmarshalInitiateUploadSessionRequest(r)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.InitiateUploadSession(ctx, req)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	MarshalUploadSessionResponse(res, w)
+}
+
+func (h *Handler) GetUploadSessionByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.GetUploadSessionByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalUploadSessionResponse(res, w)
+}
+
+// UploadChunk function receives the content of the chunk as the raw body of
+// the request.
+func (h *Handler) UploadChunk(w http.ResponseWriter, r *http.Request, id string, number string) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	chunkNumber, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("number", "must be a number"))
+		return
+	}
+
+	res, err := h.Controller.UploadChunk(ctx, objectID, int32(chunkNumber), r.Body)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalUploadSessionResponse(res, w)
+}
+
+func (h *Handler) CompleteUploadSession(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.CompleteUploadSession(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	h.marshalCreateResponse(res, w)
+}
+
+func (h *Handler) AbortUploadSession(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.AbortUploadSession(ctx, objectID); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func MarshalUploadSessionResponse(res *objectfile_c.UploadSessionResponseIDO, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
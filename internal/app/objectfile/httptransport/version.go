@@ -0,0 +1,94 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	sub_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) ListVersionsByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid value"))
+		return
+	}
+
+	res, err := h.Controller.ListVersionsByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalVersionListResponse(res, w)
+}
+
+func MarshalVersionListResponse(res []*sub_s.ObjectFileVersion, w http.ResponseWriter) {
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetVersionContentByID(w http.ResponseWriter, r *http.Request, id string, version string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid value"))
+		return
+	}
+	number, err := strconv.Atoi(version)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("version", "invalid value"))
+		return
+	}
+
+	res, err := h.Controller.GetVersionContentByID(ctx, objectID, number)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	defer res.Content.Close()
+
+	// Set content headers for file download.
+	w.Header().Set("Content-Disposition", "attachment; filename="+res.Filename)
+	w.Header().Set("Content-Type", res.ContentType)
+
+	// Stream the file content to the response body.
+	if _, err := io.Copy(w, res.Content); err != nil {
+		h.Logger.Error("failed streaming file content to response", slog.Any("error", err))
+		return
+	}
+}
+
+func (h *Handler) RestoreVersionByID(w http.ResponseWriter, r *http.Request, id string, version string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid value"))
+		return
+	}
+	number, err := strconv.Atoi(version)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("version", "invalid value"))
+		return
+	}
+
+	res, err := h.Controller.RestoreVersionByID(ctx, objectID, number)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDetailResponse(res, w)
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/cache/mongodbcache"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
+)
+
+// SessionController Interface for session business logic controller.
+type SessionController interface {
+	Create(ctx context.Context, u *user_s.User, expiry time.Duration) (string, error)
+	Rotate(ctx context.Context, sessionID string, expiry time.Duration) (*user_s.User, string, error)
+	Touch(ctx context.Context, sessionID string)
+	Revoke(ctx context.Context, sessionID string) error
+	ListByProfile(ctx context.Context) ([]*ProfileSessionResponseIDO, error)
+	RevokeByProfile(ctx context.Context, id primitive.ObjectID) error
+	RevokeAllByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	RevokeOthersByUserID(ctx context.Context, userID primitive.ObjectID, sessionID string) (int64, error)
+	SyncByUserID(ctx context.Context, userID primitive.ObjectID) error
+	VisitTenant(ctx context.Context, sessionID string, tenantID primitive.ObjectID, tenantName string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type SessionControllerImpl struct {
+	Config        *config.Conf
+	Logger        *slog.Logger
+	UUID          uuid.Provider
+	Cache         mongodbcache.Cacher
+	SessionStorer session_s.SessionStorer
+	UserStorer    user_s.UserStorer
+	AuditEvent    auditevent_c.AuditEventController
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	uuidp uuid.Provider,
+	cache mongodbcache.Cacher,
+	sess_storer session_s.SessionStorer,
+	usr_storer user_s.UserStorer,
+	ae_controller auditevent_c.AuditEventController,
+) SessionController {
+	s := &SessionControllerImpl{
+		Config:        appCfg,
+		Logger:        loggerp,
+		UUID:          uuidp,
+		Cache:         cache,
+		SessionStorer: sess_storer,
+		UserStorer:    usr_storer,
+		AuditEvent:    ae_controller,
+	}
+	s.Logger.Debug("session controller initialization started...")
+	s.Logger.Debug("session controller initialized")
+	return s
+}
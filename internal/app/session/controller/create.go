@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+)
+
+// Create function signs in the user from the device making the request and
+// returns the key of the new session.
+func (impl *SessionControllerImpl) Create(ctx context.Context, u *user_s.User, expiry time.Duration) (string, error) {
+	uBin, err := json.Marshal(u)
+	if err != nil {
+		impl.Logger.Error("marshalling error", slog.Any("err", err))
+		return "", err
+	}
+	sessionID := impl.UUID.NewUUID()
+	s := impl.newSession(ctx, u, sessionID, sessionID, time.Now(), expiry)
+	if err := impl.start(ctx, s, uBin, expiry); err != nil {
+		return "", err
+	}
+	return sessionID, nil
+}
+
+// newSession function returns the registry record of a session of the user
+// on the device making the request.
+func (impl *SessionControllerImpl) newSession(ctx context.Context, u *user_s.User, sessionID string, familyID string, signedInAt time.Time, expiry time.Duration) *session_s.Session {
+	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+	userAgent, _ := ctx.Value(constants.SessionUserAgent).(string)
+	now := time.Now()
+	return &session_s.Session{
+		ID:         primitive.NewObjectID(),
+		SessionID:  sessionID,
+		FamilyID:   familyID,
+		UserID:     u.ID,
+		TenantID:   u.TenantID,
+		Status:     session_s.StatusActive,
+		Device:     deviceFromUserAgent(userAgent),
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		CreatedAt:  signedInAt,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(expiry),
+		ModifiedAt: now,
+	}
+}
+
+// start function saves the session user in the cache and registers the
+// session.
+func (impl *SessionControllerImpl) start(ctx context.Context, s *session_s.Session, uBin []byte, expiry time.Duration) error {
+	if err := impl.Cache.SetWithExpiry(ctx, s.SessionID, uBin, expiry); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return err
+	}
+	if err := impl.SessionStorer.Create(ctx, s); err != nil {
+		impl.Logger.Error("database create error", slog.Any("err", err))
+		// Do not leave a session behind which cannot be listed nor revoked.
+		if err := impl.Cache.Delete(context.WithoutCancel(ctx), s.SessionID); err != nil {
+			impl.Logger.Error("cache delete error", slog.Any("err", err))
+		}
+		return err
+	}
+	return nil
+}
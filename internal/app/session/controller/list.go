@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+)
+
+// sessionLastSeenInterval is how stale the last seen time of a session may
+// get before a request records it again.
+const sessionLastSeenInterval = time.Minute
+
+// ProfileSessionResponseIDO is a session of the authenticated user.
+type ProfileSessionResponseIDO struct {
+	*session_s.Session
+	Current bool `json:"current"` // The session making the request.
+}
+
+// ListByProfile function returns the sessions the authenticated user is
+// signed in with.
+func (impl *SessionControllerImpl) ListByProfile(ctx context.Context) ([]*ProfileSessionResponseIDO, error) {
+	// Extract from our session the following data.
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	sessionID, _ := ctx.Value(constants.SessionID).(string)
+
+	sessions, err := impl.SessionStorer.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database list active by user id error", slog.Any("err", err))
+		return nil, err
+	}
+	res := make([]*ProfileSessionResponseIDO, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, &ProfileSessionResponseIDO{
+			Session: s,
+			Current: s.SessionID == sessionID,
+		})
+	}
+	return res, nil
+}
+
+// Touch function records the session was used now. Failures are only logged
+// as they must not fail the request.
+func (impl *SessionControllerImpl) Touch(ctx context.Context, sessionID string) {
+	if err := impl.SessionStorer.TouchBySessionID(ctx, sessionID, time.Now(), sessionLastSeenInterval); err != nil {
+		impl.Logger.Warn("failed touching session", slog.Any("err", err))
+	}
+}
+
+// PurgeExpired function deletes the registered sessions which expired.
+func (impl *SessionControllerImpl) PurgeExpired(ctx context.Context) (int64, error) {
+	count, err := impl.SessionStorer.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		impl.Logger.Error("database delete expired error", slog.Any("err", err))
+		return 0, err
+	}
+	return count, nil
+}
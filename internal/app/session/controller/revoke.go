@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// RevokeByProfile function signs out one of the sessions of the
+// authenticated user.
+func (impl *SessionControllerImpl) RevokeByProfile(ctx context.Context, id primitive.ObjectID) error {
+	// Extract from our session the following data.
+	userID := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+
+	s, err := impl.SessionStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("err", err))
+		return err
+	}
+	if s == nil || s.UserID != userID {
+		return httperror.NewForSingleField(http.StatusNotFound, "id", "does not exist")
+	}
+	return impl.Revoke(ctx, s.SessionID)
+}
+
+// RevokeAllByUserID function signs out the user from every device and
+// returns the number of sessions revoked.
+func (impl *SessionControllerImpl) RevokeAllByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	// Extract from our session the following data.
+	userRole := ctx.Value(constants.SessionUserRole).(int8)
+
+	if userRole != user_s.UserRoleExecutive {
+		return 0, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("err", err))
+		return 0, err
+	}
+	if u == nil {
+		return 0, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := policy.AuthorizeTenant(ctx, u.TenantID); err != nil {
+		impl.Logger.Warn("user does not belong to tenant", slog.Any("id", u.ID))
+		return 0, err
+	}
+
+	count, err := impl.RevokeOthersByUserID(ctx, userID, "")
+	if err != nil {
+		return count, err
+	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionRevokeSessions,
+		TargetType: auditevent_s.TargetTypeUser,
+		TargetID:   u.ID,
+		TargetName: u.Name,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return count, nil
+}
+
+// RevokeOthersByUserID function signs out the user from every device except
+// the session, if any, and returns the number of sessions revoked. Unlike
+// `RevokeAllByUserID` it is meant for the system, such as after a password
+// change, and therefore does not check permissions.
+func (impl *SessionControllerImpl) RevokeOthersByUserID(ctx context.Context, userID primitive.ObjectID, sessionID string) (int64, error) {
+	sessions, err := impl.SessionStorer.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database list active by user id error", slog.Any("err", err))
+		return 0, err
+	}
+	var count int64
+	for _, s := range sessions {
+		if s.SessionID == sessionID {
+			continue
+		}
+		if err := impl.Revoke(ctx, s.SessionID); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Revoke function signs out the session. The cached session user is deleted
+// even if the session was rotated or revoked meanwhile.
+func (impl *SessionControllerImpl) Revoke(ctx context.Context, sessionID string) error {
+	if _, err := impl.SessionStorer.UpdateStatusBySessionID(ctx, sessionID, session_s.StatusActive, session_s.StatusRevoked); err != nil {
+		return err
+	}
+	if err := impl.Cache.Delete(ctx, sessionID); err != nil {
+		impl.Logger.Error("cache delete error", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
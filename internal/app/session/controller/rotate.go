@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// Rotate function exchanges the session, identified by its refresh token,
+// for a new session on the same device and invalidates the session. A
+// refresh token can therefore only be used once; if a refresh token is used
+// again it was stolen, or the legitimate client was, so every session
+// descending from the same sign in is revoked.
+func (impl *SessionControllerImpl) Rotate(ctx context.Context, sessionID string, expiry time.Duration) (*user_s.User, string, error) {
+	s, err := impl.SessionStorer.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		impl.Logger.Error("database get by session id error", slog.Any("err", err))
+		return nil, "", err
+	}
+	if s == nil || s.Status == session_s.StatusRevoked || time.Now().After(s.ExpiresAt) {
+		impl.Logger.Warn("refresh token session does not exist")
+		return nil, "", httperror.NewForSingleField(http.StatusUnauthorized, "value", "session expired, please log in again")
+	}
+	if s.Status == session_s.StatusRotated {
+		impl.revokeReusedSession(ctx, s)
+		return nil, "", httperror.NewForSingleField(http.StatusUnauthorized, "value", "session expired, please log in again")
+	}
+
+	// Lookup in our in-memory the user record of the session.
+	uBin, err := impl.Cache.Get(ctx, sessionID)
+	if err != nil {
+		impl.Logger.Error("in-memory get error", slog.Any("err", err))
+		return nil, "", err
+	}
+	var u *user_s.User
+	if err := json.Unmarshal(uBin, &u); err != nil || u == nil {
+		impl.Logger.Error("unmarshal error", slog.Any("err", err))
+		return nil, "", httperror.NewForSingleField(http.StatusUnauthorized, "value", "session expired, please log in again")
+	}
+
+	// Claim the session. Only one of the requests racing with the same
+	// refresh token is allowed to rotate it; the others are reuse.
+	rotated, err := impl.SessionStorer.UpdateStatusBySessionID(ctx, sessionID, session_s.StatusActive, session_s.StatusRotated)
+	if err != nil {
+		return nil, "", err
+	}
+	if !rotated {
+		impl.revokeReusedSession(ctx, s)
+		return nil, "", httperror.NewForSingleField(http.StatusUnauthorized, "value", "session expired, please log in again")
+	}
+	if err := impl.Cache.Delete(ctx, sessionID); err != nil {
+		impl.Logger.Error("cache delete error", slog.Any("err", err))
+		return nil, "", err
+	}
+
+	newSessionID := impl.UUID.NewUUID()
+	ns := impl.newSession(ctx, u, newSessionID, s.FamilyID, s.CreatedAt, expiry)
+	ns.VisitedTenantID = s.VisitedTenantID
+	if err := impl.start(ctx, ns, uBin, expiry); err != nil {
+		return nil, "", err
+	}
+	return u, newSessionID, nil
+}
+
+// revokeReusedSession function revokes every session descending from the
+// same sign in as the session whose refresh token was used again.
+func (impl *SessionControllerImpl) revokeReusedSession(ctx context.Context, s *session_s.Session) {
+	impl.Logger.Warn("refresh token reuse detected, revoking sessions",
+		slog.Any("user_id", s.UserID),
+		slog.Any("session_id", s.ID))
+
+	// Revoke the sessions even if the client gave up on the request.
+	ctx = context.WithoutCancel(ctx)
+	sessions, err := impl.SessionStorer.ListActiveByFamilyID(ctx, s.FamilyID)
+	if err != nil {
+		impl.Logger.Error("database list active by family id error", slog.Any("err", err))
+		return
+	}
+	for _, fs := range sessions {
+		if err := impl.Revoke(ctx, fs.SessionID); err != nil {
+			impl.Logger.Error("failed revoking session", slog.Any("session_id", fs.ID), slog.Any("err", err))
+		}
+	}
+}
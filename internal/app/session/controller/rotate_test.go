@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/cache/mongodbcache"
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeCache keeps the cached values in memory.
+type fakeCache struct {
+	mongodbcache.Cacher
+	values map[string][]byte
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.values[key], nil
+}
+
+func (c *fakeCache) SetWithExpiry(ctx context.Context, key string, val []byte, expiry time.Duration) error {
+	c.values[key] = val
+	return nil
+}
+
+func (c *fakeCache) Delete(ctx context.Context, key string) error {
+	delete(c.values, key)
+	return nil
+}
+
+// fakeSessionStorer keeps the sessions in memory.
+type fakeSessionStorer struct {
+	session_s.SessionStorer
+	sessions []*session_s.Session
+}
+
+func (s *fakeSessionStorer) Create(ctx context.Context, m *session_s.Session) error {
+	s.sessions = append(s.sessions, m)
+	return nil
+}
+
+func (s *fakeSessionStorer) GetBySessionID(ctx context.Context, sessionID string) (*session_s.Session, error) {
+	for _, m := range s.sessions {
+		if m.SessionID == sessionID {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeSessionStorer) UpdateStatusBySessionID(ctx context.Context, sessionID string, from int8, to int8) (bool, error) {
+	m, _ := s.GetBySessionID(ctx, sessionID)
+	if m == nil || m.Status != from {
+		return false, nil
+	}
+	m.Status = to
+	return true, nil
+}
+
+func (s *fakeSessionStorer) ListActiveByFamilyID(ctx context.Context, familyID string) ([]*session_s.Session, error) {
+	var res []*session_s.Session
+	for _, m := range s.sessions {
+		if m.FamilyID == familyID && m.Status == session_s.StatusActive {
+			res = append(res, m)
+		}
+	}
+	return res, nil
+}
+
+func newRotateTestController() (*SessionControllerImpl, *fakeCache, *fakeSessionStorer) {
+	cache := &fakeCache{values: map[string][]byte{}}
+	storer := &fakeSessionStorer{}
+	c := &SessionControllerImpl{
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		UUID:          uuid.NewProvider(),
+		Cache:         cache,
+		SessionStorer: storer,
+	}
+	return c, cache, storer
+}
+
+func TestRotateInvalidatesPreviousSession(t *testing.T) {
+	c, cache, _ := newRotateTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID(), Email: "staff@example.com"}
+
+	first, err := c.Create(ctx, u, time.Hour)
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	ru, second, err := c.Rotate(ctx, first, time.Hour)
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if ru.ID != u.ID || second == first {
+		t.Fatalf("expected a new session of the user but received %v for %v", second, ru.ID)
+	}
+	if _, ok := cache.values[first]; ok {
+		t.Errorf("expected the previous session to be invalidated")
+	}
+	var cached user_s.User
+	if err := json.Unmarshal(cache.values[second], &cached); err != nil || cached.Email != u.Email {
+		t.Errorf("expected the new session to hold the user but received %v", err)
+	}
+}
+
+func TestRotateReuseRevokesSessionFamily(t *testing.T) {
+	c, cache, storer := newRotateTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID()}
+
+	first, _ := c.Create(ctx, u, time.Hour)
+	other, _ := c.Create(ctx, u, time.Hour) // Another device of the same user.
+	_, second, err := c.Rotate(ctx, first, time.Hour)
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	// Using the first refresh token again is reuse.
+	_, _, err = c.Rotate(ctx, first, time.Hour)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected unauthorized error but received %v", err)
+	}
+	if _, ok := cache.values[second]; ok {
+		t.Errorf("expected the session rotated from the reused session to be revoked")
+	}
+	if s, _ := storer.GetBySessionID(ctx, second); s.Status != session_s.StatusRevoked {
+		t.Errorf("expected revoked status but received %d", s.Status)
+	}
+	if _, ok := cache.values[other]; !ok {
+		t.Errorf("expected the sessions of other sign ins to be kept")
+	}
+}
+
+func TestDeviceFromUserAgent(t *testing.T) {
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	if got := deviceFromUserAgent(ua); got != "Chrome on Windows" {
+		t.Errorf("expected Chrome on Windows but received %q", got)
+	}
+	if got := deviceFromUserAgent(""); got != "Unknown device" {
+		t.Errorf("expected unknown device but received %q", got)
+	}
+}
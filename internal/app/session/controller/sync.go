@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+)
+
+// SyncByUserID function replaces the user cached in every session of the user
+// with the user record so a change to the user, such as a new role or tenant,
+// takes effect on the next request instead of the next sign in. The sessions
+// are revoked if the user was deleted or archived.
+func (impl *SessionControllerImpl) SyncByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	// Finish invalidating the sessions even if the client gave up on the
+	// request; the change to the user was already saved.
+	ctx = context.WithoutCancel(ctx)
+
+	u, err := impl.UserStorer.GetByID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("err", err))
+		return err
+	}
+	if u == nil || u.Status == user_s.UserStatusArchived {
+		count, err := impl.RevokeOthersByUserID(ctx, userID, "")
+		if err != nil {
+			return err
+		}
+		impl.Logger.Debug("revoked sessions of removed user", slog.Any("user_id", userID), slog.Int64("count", count))
+		return nil
+	}
+
+	sessions, err := impl.SessionStorer.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		impl.Logger.Error("database list active by user id error", slog.Any("err", err))
+		return err
+	}
+	for _, s := range sessions {
+		if err := impl.sync(ctx, s, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sync function replaces the user cached in the session. Whether the user
+// validated their 2FA code belongs to the session, not the user, so it is
+// kept; so is the tenant an executive is visiting with the session.
+func (impl *SessionControllerImpl) sync(ctx context.Context, s *session_s.Session, u *user_s.User) error {
+	expiry := time.Until(s.ExpiresAt)
+	if expiry <= 0 {
+		return nil
+	}
+	cBin, err := impl.Cache.Get(ctx, s.SessionID)
+	if err != nil || len(cBin) == 0 {
+		// The session expired from the cache meanwhile; nothing to update.
+		impl.Logger.Warn("session user is not cached", slog.Any("err", err))
+		return nil
+	}
+	var cached user_s.User
+	if err := json.Unmarshal(cBin, &cached); err != nil {
+		impl.Logger.Error("unmarshal error", slog.Any("err", err))
+		return err
+	}
+
+	su := *u
+	su.OTPValidated = cached.OTPValidated
+	if su.Role == user_s.UserRoleExecutive && !s.VisitedTenantID.IsZero() {
+		su.TenantID = cached.TenantID
+		su.TenantName = cached.TenantName
+	}
+	uBin, err := json.Marshal(&su)
+	if err != nil {
+		impl.Logger.Error("marshalling error", slog.Any("err", err))
+		return err
+	}
+	if err := impl.Cache.SetWithExpiry(ctx, s.SessionID, uBin, expiry); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
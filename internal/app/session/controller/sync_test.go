@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+)
+
+func (s *fakeSessionStorer) ListActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*session_s.Session, error) {
+	var res []*session_s.Session
+	for _, m := range s.sessions {
+		if m.UserID == userID && m.Status == session_s.StatusActive {
+			res = append(res, m)
+		}
+	}
+	return res, nil
+}
+
+// fakeUserStorer returns a single user.
+type fakeUserStorer struct {
+	user_s.UserStorer
+	user *user_s.User
+}
+
+func (s *fakeUserStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*user_s.User, error) {
+	return s.user, nil
+}
+
+func TestSyncByUserIDReplacesCachedUser(t *testing.T) {
+	c, cache, _ := newRotateTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID(), Role: user_s.UserRoleExecutive, OTPEnabled: true, OTPVerified: true, OTPValidated: true}
+	c.UserStorer = &fakeUserStorer{user: u}
+
+	sessionID, _ := c.Create(ctx, u, time.Hour)
+
+	changed := *u
+	changed.Role = user_s.UserRoleFrontlineStaff
+	changed.OTPValidated = false // Another device signed in.
+	c.UserStorer = &fakeUserStorer{user: &changed}
+	if err := c.SyncByUserID(ctx, u.ID); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	var cached user_s.User
+	if err := json.Unmarshal(cache.values[sessionID], &cached); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if cached.Role != user_s.UserRoleFrontlineStaff {
+		t.Errorf("expected the new role but received %d", cached.Role)
+	}
+	if !cached.OTPValidated {
+		t.Errorf("expected the 2FA validation of the session to be kept")
+	}
+}
+
+func (s *fakeSessionStorer) UpdateVisitedTenantIDBySessionID(ctx context.Context, sessionID string, tenantID primitive.ObjectID) error {
+	if m, _ := s.GetBySessionID(ctx, sessionID); m != nil {
+		m.VisitedTenantID = tenantID
+	}
+	return nil
+}
+
+func TestSyncByUserIDKeepsVisitedTenant(t *testing.T) {
+	c, cache, _ := newRotateTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID(), Role: user_s.UserRoleExecutive, TenantID: primitive.NewObjectID()}
+	c.UserStorer = &fakeUserStorer{user: u}
+
+	sessionID, _ := c.Create(ctx, u, time.Hour)
+	visitedID := primitive.NewObjectID()
+	if err := c.VisitTenant(ctx, sessionID, visitedID, "Visited"); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	if err := c.SyncByUserID(ctx, u.ID); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	var cached user_s.User
+	if err := json.Unmarshal(cache.values[sessionID], &cached); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if cached.TenantID != visitedID {
+		t.Errorf("expected the visited tenant to be kept but received %v", cached.TenantID)
+	}
+}
+
+func TestSyncByUserIDMovesExecutiveToNewTenant(t *testing.T) {
+	c, cache, _ := newRotateTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID(), Role: user_s.UserRoleExecutive, TenantID: primitive.NewObjectID()}
+	c.UserStorer = &fakeUserStorer{user: u}
+
+	sessionID, _ := c.Create(ctx, u, time.Hour)
+
+	changed := *u
+	changed.TenantID = primitive.NewObjectID()
+	c.UserStorer = &fakeUserStorer{user: &changed}
+	if err := c.SyncByUserID(ctx, u.ID); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+
+	var cached user_s.User
+	if err := json.Unmarshal(cache.values[sessionID], &cached); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if cached.TenantID != changed.TenantID {
+		t.Errorf("expected the new tenant of the user but received %v", cached.TenantID)
+	}
+}
+
+func TestSyncByUserIDRevokesArchivedUser(t *testing.T) {
+	c, cache, _ := newRotateTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID()}
+	c.UserStorer = &fakeUserStorer{user: u}
+
+	sessionID, _ := c.Create(ctx, u, time.Hour)
+
+	u.Status = user_s.UserStatusArchived
+	if err := c.SyncByUserID(ctx, u.ID); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if _, ok := cache.values[sessionID]; ok {
+		t.Errorf("expected the session of the archived user to be revoked")
+	}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	session_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// VisitTenant function scopes the session to the tenant so an executive may
+// work on the tenant's records with the tokens they already have. The visit
+// is recorded on the session so syncing the user record does not send the
+// executive back to their own tenant until they sign in again.
+func (impl *SessionControllerImpl) VisitTenant(ctx context.Context, sessionID string, tenantID primitive.ObjectID, tenantName string) error {
+	s, err := impl.SessionStorer.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		impl.Logger.Error("database get by session id error", slog.Any("err", err))
+		return err
+	}
+	if s == nil || s.Status != session_s.StatusActive || time.Now().After(s.ExpiresAt) {
+		impl.Logger.Warn("visiting session does not exist")
+		return httperror.NewForSingleField(http.StatusUnauthorized, "message", "session expired, please log in again")
+	}
+
+	// Lookup in our in-memory the user record of the session.
+	uBin, err := impl.Cache.Get(ctx, sessionID)
+	if err != nil {
+		impl.Logger.Error("in-memory get error", slog.Any("err", err))
+		return err
+	}
+	var u *user_s.User
+	if err := json.Unmarshal(uBin, &u); err != nil || u == nil {
+		impl.Logger.Error("unmarshal error", slog.Any("err", err))
+		return httperror.NewForSingleField(http.StatusUnauthorized, "message", "session expired, please log in again")
+	}
+
+	// Record the visit before changing the cached user so a sync running
+	// meanwhile does not discard the visited tenant.
+	if err := impl.SessionStorer.UpdateVisitedTenantIDBySessionID(ctx, sessionID, tenantID); err != nil {
+		return err
+	}
+
+	u.TenantID = tenantID
+	u.TenantName = tenantName
+	uBin, err = json.Marshal(u)
+	if err != nil {
+		impl.Logger.Error("marshalling error", slog.Any("err", err))
+		return err
+	}
+	if err := impl.Cache.SetWithExpiry(ctx, sessionID, uBin, time.Until(s.ExpiresAt)); err != nil {
+		impl.Logger.Error("cache set with expiry error", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl SessionStorerImpl) Create(ctx context.Context, m *Session) error {
+	if m.ID == primitive.NilObjectID {
+		m.ID = primitive.NewObjectID()
+		impl.Logger.Warn("database insert session not included id value, created id now.", slog.Any("id", m.ID))
+	}
+
+	if _, err := impl.Collection.InsertOne(ctx, m); err != nil {
+		impl.Logger.Error("database insert error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+const (
+	StatusActive  = 1 // The session can be used.
+	StatusRotated = 2 // The refresh token of the session was exchanged for a new session.
+	StatusRevoked = 3 // The user signed out or the session was revoked.
+)
+
+// Session represents a signed in device of a user. The session user itself
+// is kept in the cache under the `SessionID` key, this record is the registry
+// used to list and revoke the sessions of a user.
+type Session struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	SessionID string             `bson:"session_id" json:"-"`
+	FamilyID  string             `bson:"family_id" json:"-"` // Shared by every session rotated from the same sign in.
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TenantID  primitive.ObjectID `bson:"tenant_id" json:"tenant_id"`
+	// The tenant an executive is visiting with the session, if any.
+	VisitedTenantID primitive.ObjectID `bson:"visited_tenant_id,omitempty" json:"visited_tenant_id,omitempty"`
+	Status          int8               `bson:"status" json:"status"`
+	Device          string             `bson:"device" json:"device"`
+	IPAddress       string             `bson:"ip_address" json:"ip_address"`
+	UserAgent       string             `bson:"user_agent" json:"user_agent"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"` // When the user signed in.
+	LastSeenAt      time.Time          `bson:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt       time.Time          `bson:"expires_at" json:"expires_at"`
+	ModifiedAt      time.Time          `bson:"modified_at" json:"modified_at"`
+}
+
+// SessionStorer Interface for session.
+type SessionStorer interface {
+	Create(ctx context.Context, m *Session) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Session, error)
+	GetBySessionID(ctx context.Context, sessionID string) (*Session, error)
+	UpdateStatusBySessionID(ctx context.Context, sessionID string, from int8, to int8) (bool, error)
+	TouchBySessionID(ctx context.Context, sessionID string, seenAt time.Time, interval time.Duration) error
+	UpdateVisitedTenantIDBySessionID(ctx context.Context, sessionID string, tenantID primitive.ObjectID) error
+	ListActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*Session, error)
+	ListActiveByFamilyID(ctx context.Context, familyID string) ([]*Session, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type SessionStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) SessionStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("sessions")
+
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "session_id", Value: 1}}},
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &SessionStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
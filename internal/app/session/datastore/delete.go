@@ -0,0 +1,24 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DeleteExpired function deletes the sessions which expired before the
+// time. Rotated sessions are kept until they expire so a reused refresh token
+// is still recognized.
+func (impl SessionStorerImpl) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res, err := impl.Collection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lte": before}})
+	if err != nil {
+		impl.Logger.Error("database delete expired error", slog.Any("error", err))
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
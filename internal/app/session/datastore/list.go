@@ -0,0 +1,49 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListActiveByUserID function returns the unexpired sessions of the user,
+// most recently used first.
+func (impl SessionStorerImpl) ListActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]*Session, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"status":     StatusActive,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+	return impl.list(ctx, filter, opts)
+}
+
+// ListActiveByFamilyID function returns the sessions, which were not revoked
+// nor rotated, that descend from the same sign in.
+func (impl SessionStorerImpl) ListActiveByFamilyID(ctx context.Context, familyID string) ([]*Session, error) {
+	filter := bson.M{
+		"family_id": familyID,
+		"status":    StatusActive,
+	}
+	return impl.list(ctx, filter, options.Find())
+}
+
+func (impl SessionStorerImpl) list(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	cur, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var sessions []*Session
+	if err := cur.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
@@ -0,0 +1,73 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateStatusBySessionID function changes the status of the session only
+// if the session still has the `from` status and returns whether it did. The
+// check and the change are a single operation so two requests racing to
+// rotate or revoke the same session cannot both succeed.
+func (impl SessionStorerImpl) UpdateStatusBySessionID(ctx context.Context, sessionID string, from int8, to int8) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"session_id": sessionID, "status": from}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      to,
+			"modified_at": time.Now(),
+		},
+	}
+	res, err := impl.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update status by session id error", slog.Any("error", err))
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// TouchBySessionID function records the session was used. The record is
+// written at most once per interval to keep requests from writing on every
+// call.
+func (impl SessionStorerImpl) TouchBySessionID(ctx context.Context, sessionID string, seenAt time.Time, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"session_id":   sessionID,
+		"status":       StatusActive,
+		"last_seen_at": bson.M{"$lt": seenAt.Add(-interval)},
+	}
+	update := bson.M{"$set": bson.M{"last_seen_at": seenAt}}
+	if _, err := impl.Collection.UpdateOne(ctx, filter, update); err != nil {
+		impl.Logger.Error("database touch by session id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// UpdateVisitedTenantIDBySessionID function records the tenant the executive
+// is visiting with the session.
+func (impl SessionStorerImpl) UpdateVisitedTenantIDBySessionID(ctx context.Context, sessionID string, tenantID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"session_id": sessionID, "status": StatusActive}
+	update := bson.M{
+		"$set": bson.M{
+			"visited_tenant_id": tenantID,
+			"modified_at":       time.Now(),
+		},
+	}
+	if _, err := impl.Collection.UpdateOne(ctx, filter, update); err != nil {
+		impl.Logger.Error("database update visited tenant id by session id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) ListByProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res, err := h.Controller.ListByProfile(ctx)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,52 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// RevokeAllResponseIDO is the number of sessions signed out.
+type RevokeAllResponseIDO struct {
+	Count int64 `json:"count"`
+}
+
+func (h *Handler) RevokeByProfile(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.RevokeByProfile(ctx, objectID); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RevokeAllByUserID(w http.ResponseWriter, r *http.Request, userID string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	count, err := h.Controller.RevokeAllByUserID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&RevokeAllResponseIDO{Count: count}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+const (
+	// A visitor is refused after `publicPasswordAttemptLimit` wrong
+	// passphrases for the link within `publicPasswordAttemptWindow`.
+	publicPasswordAttemptLimit  = 5
+	publicPasswordAttemptWindow = 15 * time.Minute
+)
+
+// consumePublicAccess function verifies the shareable link can be used by an
+// unauthenticated visitor and, if so, counts this access against the link's
+// maximum access count. If the `objectFileID` parameter is provided then the
+// object file must belong to the link's smart folder, else no access is
+// counted and an error is returned. Every rule returns a distinct HTTP status code so the
+// frontend can explain to the visitor why access was denied:
+//
+//	400 - link does not exist or has expired.
+//	410 - link was revoked or archived by staff, or its smart folder deleted.
+//	401 - link requires a passphrase which was missing or incorrect.
+//	403 - link reached its maximum access count.
+//	404 - object file does not belong to the link.
+//	429 - too many incorrect passphrases were entered from the IP address.
+func (c *ShareableLinkControllerImpl) consumePublicAccess(ctx context.Context, id primitive.ObjectID, password string, objectFileID primitive.ObjectID) (*shareablelink_s.ShareableLink, *objectfile_s.ObjectFile, error) {
+	// Retrieve from our database the record for the specific id.
+	sl, err := c.ShareableLinkStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("failed getting shareable link by id",
+			slog.Any("error", err))
+		return nil, nil, err
+	}
+
+	// Check to see if the `id` exists in our database.
+	if sl == nil {
+		c.Logger.Warn(fmt.Sprintf("shareable link does not exist for id: %s", id.Hex()))
+		return nil, nil, httperror.NewForBadRequestWithSingleField("id", fmt.Sprintf("shareable link does not exist for id: %s", id.Hex()))
+	}
+
+	// Check to see if the link was revoked or archived.
+	if sl.Status != shareablelink_s.StatusActive && sl.Status != shareablelink_s.StatusExpired {
+		c.Logger.Warn("shareable link is no longer active",
+			slog.Any("id", id),
+			slog.Any("status", sl.Status))
+		return nil, nil, httperror.NewForSingleField(http.StatusGone, "id", "shareable link was revoked")
+	}
+
+	// Check to see if the smart folder was moved to the trash.
+	sf, err := c.SmartFolderStorer.GetByID(ctx, sl.SmartFolderID)
+	if err != nil {
+		c.Logger.Error("failed getting smart folder by id",
+			slog.Any("error", err))
+		return nil, nil, err
+	}
+	if sf == nil || sf.IsTrashed() {
+		c.Logger.Warn("shareable link smart folder was deleted",
+			slog.Any("id", id),
+			slog.Any("smart_folder_id", sl.SmartFolderID))
+		return nil, nil, httperror.NewForSingleField(http.StatusGone, "id", "shareable link was revoked")
+	}
+
+	// Check to see if the link expired.
+	if time.Now().After(sl.ExpiryDate) {
+		c.Logger.Warn(fmt.Sprintf("shareable link expired at: %s", sl.ExpiryDate))
+		return nil, nil, httperror.NewForBadRequestWithSingleField("id", fmt.Sprintf("shareable link expired at: %s", sl.ExpiryDate))
+	}
+
+	// Check to see if the passphrase matches.
+	if sl.IsPasswordProtected {
+		if password == "" {
+			c.Logger.Warn("shareable link requires password", slog.Any("id", id))
+			return nil, nil, httperror.NewForSingleField(http.StatusUnauthorized, "password", "shareable link requires a password")
+		}
+		if err := c.checkPublicPasswordAttempts(ctx, sl); err != nil {
+			return nil, nil, err
+		}
+		passwordMatch, _ := c.Password.ComparePasswordAndHash(password, sl.PasswordHash)
+		if !passwordMatch {
+			c.Logger.Warn("shareable link password does not match", slog.Any("id", id))
+			if err := c.recordPublicAccess(ctx, sl, sla_s.TypeFailedPassword, nil); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, httperror.NewForSingleField(http.StatusUnauthorized, "password", "password does not match")
+		}
+	}
+
+	// Check to see if the link was used up.
+	if sl.MaxAccessCount > 0 && sl.AccessCount >= sl.MaxAccessCount {
+		c.Logger.Warn("shareable link reached maximum access count",
+			slog.Any("id", id),
+			slog.Any("max_access_count", sl.MaxAccessCount))
+		return nil, nil, httperror.NewForForbiddenWithSingleField("id", "shareable link reached its maximum number of views and downloads")
+	}
+
+	// Check to see if the requested object file belongs to the link.
+	var of *objectfile_s.ObjectFile
+	if !objectFileID.IsZero() {
+		of, err = c.ObjectFileStorer.GetByID(ctx, objectFileID)
+		if err != nil {
+			c.Logger.Error("failed getting object file by id",
+				slog.Any("object_file_id", objectFileID),
+				slog.Any("error", err))
+			return nil, nil, err
+		}
+		if of == nil || of.SmartFolderID != sl.SmartFolderID || of.TenantID != sl.TenantID || of.Status != objectfile_s.StatusActive || of.IsTrashed() {
+			c.Logger.Warn("object file does not belong to shareable link",
+				slog.Any("id", id),
+				slog.Any("object_file_id", objectFileID))
+			return nil, nil, httperror.NewForSingleField(http.StatusNotFound, "object_file_id", "object file does not exist for this shareable link")
+		}
+	}
+
+	// Count this access. The maximum access count is checked again by the
+	// update as concurrent visitors may have used up the link since our read.
+	counted, err := c.ShareableLinkStorer.IncrementAccessCountByID(ctx, sl.ID)
+	if err != nil {
+		c.Logger.Error("shareable link increment access count by id error", slog.Any("error", err))
+		return nil, nil, err
+	}
+	if !counted {
+		c.Logger.Warn("shareable link reached maximum access count",
+			slog.Any("id", id),
+			slog.Any("max_access_count", sl.MaxAccessCount))
+		return nil, nil, httperror.NewForForbiddenWithSingleField("id", "shareable link reached its maximum number of views and downloads")
+	}
+	sl.AccessCount++
+
+	return sl, of, nil
+}
+
+// checkPublicPasswordAttempts function returns a `429 Too Many Requests`
+// error if the visitor entered too many incorrect passphrases for the link
+// recently so passphrases cannot be guessed.
+func (c *ShareableLinkControllerImpl) checkPublicPasswordAttempts(ctx context.Context, sl *shareablelink_s.ShareableLink) error {
+	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+	count, err := c.ShareableLinkAccessStorer.CountByIPAddressSince(ctx, sl.ID, sla_s.TypeFailedPassword, ipAddress, time.Now().Add(-publicPasswordAttemptWindow))
+	if err != nil {
+		c.Logger.Error("failed counting shareable link password attempts", slog.Any("error", err))
+		return err
+	}
+	if count >= publicPasswordAttemptLimit {
+		c.Logger.Warn("shareable link password attempts exceeded",
+			slog.Any("id", sl.ID),
+			slog.String("ip_address", ipAddress))
+		return httperror.NewForSingleField(http.StatusTooManyRequests, "password", "too many incorrect passwords, please try again later")
+	}
+	return nil
+}
+
+// recordPublicAccess function appends an access event for the visitor to the
+// shareable link access log. The `of` parameter is optional and is only
+// provided when the visitor is downloading a particular object file.
+func (c *ShareableLinkControllerImpl) recordPublicAccess(ctx context.Context, sl *shareablelink_s.ShareableLink, accessType int8, of *objectfile_s.ObjectFile) error {
+	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+	userAgent, _ := ctx.Value(constants.SessionUserAgent).(string)
+
+	a := &sla_s.ShareableLinkAccess{
+		ID:              primitive.NewObjectID(),
+		ShareableLinkID: sl.ID,
+		SmartFolderID:   sl.SmartFolderID,
+		Type:            accessType,
+		IPAddress:       ipAddress,
+		UserAgent:       userAgent,
+		CreatedAt:       time.Now(),
+		TenantID:        sl.TenantID,
+	}
+	if of != nil {
+		a.ObjectFileID = of.ID
+		a.ObjectFileName = of.Filename
+	}
+	if err := c.ShareableLinkAccessStorer.Create(ctx, a); err != nil {
+		c.Logger.Error("failed recording shareable link access",
+			slog.Any("shareable_link_id", sl.ID),
+			slog.Any("error", err))
+		return err
+	}
+	return nil
+}
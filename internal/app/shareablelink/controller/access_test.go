@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeShareableLinkAccessStorer counts the failed passphrases of a single
+// IP address. Unimplemented methods panic.
+type fakeShareableLinkAccessStorer struct {
+	sla_s.ShareableLinkAccessStorer
+	ipAddress string
+	failed    int64
+}
+
+func (s *fakeShareableLinkAccessStorer) CountByIPAddressSince(ctx context.Context, shareableLinkID primitive.ObjectID, accessType int8, ipAddress string, since time.Time) (int64, error) {
+	if accessType != sla_s.TypeFailedPassword || ipAddress != s.ipAddress {
+		return 0, nil
+	}
+	return s.failed, nil
+}
+
+func TestPublicPasswordAttemptsAreLimited(t *testing.T) {
+	sl := &shareablelink_s.ShareableLink{ID: primitive.NewObjectID()}
+	storer := &fakeShareableLinkAccessStorer{ipAddress: "10.0.0.1", failed: publicPasswordAttemptLimit - 1}
+	c := &ShareableLinkControllerImpl{
+		Logger:                    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ShareableLinkAccessStorer: storer,
+	}
+	ctx := context.WithValue(context.Background(), constants.SessionIPAddress, "10.0.0.1")
+
+	if err := c.checkPublicPasswordAttempts(ctx, sl); err != nil {
+		t.Fatalf("expected attempt below the limit to be allowed but received %v", err)
+	}
+
+	storer.failed = publicPasswordAttemptLimit
+	err := c.checkPublicPasswordAttempts(ctx, sl)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected too many requests error but received %v", err)
+	}
+
+	// Other visitors are not refused because of the failures of another.
+	other := context.WithValue(context.Background(), constants.SessionIPAddress, "10.0.0.2")
+	if err := c.checkPublicPasswordAttempts(other, sl); err != nil {
+		t.Fatalf("expected another ip address to be allowed but received %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (impl *ShareableLinkControllerImpl) ArchiveByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error) {
+	// Extract from our session the following data.
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName, _ := ctx.Value(constants.SessionUserName).(string)
+	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
+
+	// Lookup the shareablelink in our database, else return a `400 Bad Request` error.
+	ou, err := impl.ShareableLinkStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return nil, err
+	}
+	if ou == nil {
+		impl.Logger.Warn("shareablelink does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := impl.authorizeShareableLink(ctx, ou, policy.PermissionShare); err != nil {
+		return nil, err
+	}
+
+	before := auditevent_c.Snapshot(ou)
+	ou.Status = shareablelink_s.StatusArchived
+	ou.ModifiedAt = time.Now()
+	ou.ModifiedByUserID = userID
+	ou.ModifiedByUserName = userName
+	ou.ModifiedFromIPAddress = ipAddress
+
+	if err := impl.ShareableLinkStorer.UpdateByID(ctx, ou); err != nil {
+		impl.Logger.Error("shareablelink update by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionArchive,
+		TargetType: auditevent_s.TargetTypeShareableLink,
+		TargetID:   ou.ID,
+		TargetName: ou.SmartFolderName,
+		Before:     before,
+		After:      ou,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return ou, nil
+}
@@ -9,9 +9,13 @@ import (
 
 	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/templatedemailer"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
 	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
 	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/kmutex"
@@ -23,28 +27,35 @@ import (
 type ShareableLinkController interface {
 	Create(ctx context.Context, requestData *ShareableLinkCreateRequestIDO) (*shareablelink_s.ShareableLink, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error)
-	PublicGetByID(ctx context.Context, id primitive.ObjectID) (*PublicShareableLinkResponseIDO, error)
-	// UpdateByID(ctx context.Context, requestData *ShareableLinkUpdateRequestIDO) (*shareablelink_s.ShareableLink, error)
-	// ListByFilter(ctx context.Context, f *shareablelink_s.ShareableLinkPaginationListFilter) (*shareablelink_s.ShareableLinkPaginationListResult, error)
-	// ListAsSelectOptionByFilter(ctx context.Context, f *shareablelink_s.ShareableLinkPaginationListFilter) ([]*shareablelink_s.ShareableLinkAsSelectOption, error)
-	// PublicListAsSelectOptionByFilter(ctx context.Context, f *shareablelink_s.ShareableLinkPaginationListFilter) ([]*shareablelink_s.ShareableLinkAsSelectOption, error)
-	// ArchiveByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error)
-	// DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	PublicGetByID(ctx context.Context, id primitive.ObjectID, password string) (*PublicShareableLinkResponseIDO, error)
+	PublicGetObjectFileContent(ctx context.Context, id primitive.ObjectID, objectFileID primitive.ObjectID, password string) (*PublicObjectFileContentResponseIDO, error)
+	PublicGetObjectFilePresignedURL(ctx context.Context, id primitive.ObjectID, objectFileID primitive.ObjectID, password string) (*PublicObjectFilePresignedURLResponseIDO, error)
+	PublicGetArchive(ctx context.Context, id primitive.ObjectID, password string) (*objectfile_c.Archive, error)
+	RevokeByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error)
+	ListAccessesByFilter(ctx context.Context, f *sla_s.ShareableLinkAccessListFilter) (*ShareableLinkAccessListResponseIDO, error)
+	UpdateByID(ctx context.Context, requestData *ShareableLinkUpdateRequestIDO) (*shareablelink_s.ShareableLink, error)
+	ListByFilter(ctx context.Context, f *shareablelink_s.ShareableLinkPaginationListFilter) (*shareablelink_s.ShareableLinkPaginationListResult, error)
+	ArchiveByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	ExpireOverdue(ctx context.Context) (int64, error)
 }
 
 type ShareableLinkControllerImpl struct {
-	Config             *config.Conf
-	Logger             *slog.Logger
-	UUID               uuid.Provider
-	ObjectStorage      object_storage.ObjectStorager
-	Password           password.Provider
-	Kmutex             kmutex.Provider
-	DbClient           *mongo.Client
-	UserStorer         user_s.UserStorer
-	ShareableLinkStorer shareablelink_s.ShareableLinkStorer
-	SmartFolderStorer  smartfolder_s.SmartFolderStorer
-	ObjectFileStorer   objectfile_s.ObjectFileStorer
-	TemplatedEmailer   templatedemailer.TemplatedEmailer
+	Config                    *config.Conf
+	Logger                    *slog.Logger
+	UUID                      uuid.Provider
+	ObjectStorage             object_storage.ObjectStorager
+	Password                  password.Provider
+	Kmutex                    kmutex.Provider
+	DbClient                  *mongo.Client
+	UserStorer                user_s.UserStorer
+	ShareableLinkStorer       shareablelink_s.ShareableLinkStorer
+	ShareableLinkAccessStorer sla_s.ShareableLinkAccessStorer
+	SmartFolderStorer         smartfolder_s.SmartFolderStorer
+	ObjectFileStorer          objectfile_s.ObjectFileStorer
+	TenantStorer              tenant_s.TenantStorer
+	TemplatedEmailer          templatedemailer.TemplatedEmailer
+	AuditEvent                auditevent_c.AuditEventController
 }
 
 func NewController(
@@ -58,22 +69,28 @@ func NewController(
 	client *mongo.Client,
 	usr_storer user_s.UserStorer,
 	shareablelink_s shareablelink_s.ShareableLinkStorer,
+	sla_storer sla_s.ShareableLinkAccessStorer,
 	smartfolder_s smartfolder_s.SmartFolderStorer,
 	obj_storer objectfile_s.ObjectFileStorer,
+	tenant_storer tenant_s.TenantStorer,
+	ae_controller auditevent_c.AuditEventController,
 ) ShareableLinkController {
 	s := &ShareableLinkControllerImpl{
-		Config:             appCfg,
-		Logger:             loggerp,
-		UUID:               uuidp,
-		ObjectStorage:      object,
-		Password:           passwordp,
-		Kmutex:             kmux,
-		TemplatedEmailer:   temailer,
-		DbClient:           client,
-		UserStorer:         usr_storer,
-		ShareableLinkStorer: shareablelink_s,
-		SmartFolderStorer:  smartfolder_s,
-		ObjectFileStorer:   obj_storer,
+		Config:                    appCfg,
+		Logger:                    loggerp,
+		UUID:                      uuidp,
+		ObjectStorage:             object,
+		Password:                  passwordp,
+		Kmutex:                    kmux,
+		TemplatedEmailer:          temailer,
+		DbClient:                  client,
+		UserStorer:                usr_storer,
+		ShareableLinkStorer:       shareablelink_s,
+		ShareableLinkAccessStorer: sla_storer,
+		SmartFolderStorer:         smartfolder_s,
+		ObjectFileStorer:          obj_storer,
+		TenantStorer:              tenant_storer,
+		AuditEvent:                ae_controller,
 	}
 	s.Logger.Debug("shareablelink controller initialization started...")
 	s.Logger.Debug("shareablelink controller initialized")
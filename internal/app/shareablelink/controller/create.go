@@ -9,14 +9,19 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 type ShareableLinkCreateRequestIDO struct {
-	SmartFolderID primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
-	ExpiresIn     uint64             `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
+	SmartFolderID  primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
+	ExpiresIn      uint64             `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
+	Password       string             `bson:"password,omitempty" json:"password,omitempty"`
+	MaxAccessCount uint64             `bson:"max_access_count,omitempty" json:"max_access_count,omitempty"`
 }
 
 func (impl *ShareableLinkControllerImpl) validateCreateRequest(ctx context.Context, dirtyData *ShareableLinkCreateRequestIDO) error {
@@ -35,6 +40,9 @@ func (impl *ShareableLinkControllerImpl) validateCreateRequest(ctx context.Conte
 	return nil
 }
 
+// Create function creates a shareable link for the smart folder. It is also
+// used by the smart folder controller to generate links so every link is
+// created the same way.
 func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *ShareableLinkCreateRequestIDO) (*shareablelink_s.ShareableLink, error) {
 	//
 	// Get variables from our user authenticated session.
@@ -47,18 +55,6 @@ func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *Sharea
 	userName, _ := ctx.Value(constants.SessionUserName).(string)
 	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
 
-	// DEVELOPERS NOTE:
-	// Every submission needs to have a unique `public id` (PID)
-	// generated. The following needs to happen to generate the unique PID:
-	// 1. Make the `Create` function be `atomic` and thus lock this function.
-	// 2. Count total records in system (for particular tenant).
-	// 3. Generate PID.
-	// 4. Apply the PID to the record.
-	// 5. Unlock this `Create` function to be usable again by other calls after
-	//    the function successfully submits the record into our system.
-	impl.Kmutex.Lockf("create-smart-folder-by-tenant-%s", tid.Hex())
-	defer impl.Kmutex.Unlockf("create-smart-folder-by-tenant-%s", tid.Hex())
-
 	//
 	// Perform our validation and return validation error on any issues detected.
 	//
@@ -68,6 +64,10 @@ func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *Sharea
 		return nil, err
 	}
 
+	if err := policy.AuthorizeVerifiedEmailForTenant(ctx, impl.TenantStorer, impl.Logger); err != nil {
+		return nil, err
+	}
+
 	// switch role {
 	// case u_s.UserRoleExecutive, u_s.UserRoleManagement, u_s.UserRoleFrontlineStaff:
 	// 	break
@@ -88,6 +88,9 @@ func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *Sharea
 	}
 	defer session.EndSession(ctx)
 
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 		sf, err := impl.SmartFolderStorer.GetByID(sessCtx, req.SmartFolderID)
@@ -96,11 +99,16 @@ func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *Sharea
 				slog.Any("error", err))
 			return nil, err
 		}
-		if sf == nil {
+		if sf == nil || sf.IsTrashed() {
 			impl.Logger.Warn("smart folder does not exist",
 				slog.Any("smart_folder_id", req.SmartFolderID))
 			return nil, httperror.NewForSingleField(http.StatusBadRequest, "smart_folder_id", "smart folder does not exist")
 		}
+		if err := policy.AuthorizeRecord(ctx, sf.TenantID, sf.AccessControlList, policy.PermissionShare); err != nil {
+			impl.Logger.Warn("smart folder access denied",
+				slog.Any("smart_folder_id", req.SmartFolderID))
+			return nil, err
+		}
 
 		sl := &shareablelink_s.ShareableLink{}
 
@@ -126,12 +134,32 @@ func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *Sharea
 		sl.SmartFolderSubCategory = sf.SubCategory
 		sl.SmartFolderDescription = sf.Description
 		sl.Status = shareablelink_s.StatusActive
+		sl.MaxAccessCount = req.MaxAccessCount
+
+		// Add optional passphrase protection.
+		if req.Password != "" {
+			passwordHash, err := impl.Password.GenerateHashFromPassword(req.Password)
+			if err != nil {
+				impl.Logger.Error("hashing error", slog.Any("error", err))
+				return nil, err
+			}
+			sl.IsPasswordProtected = true
+			sl.PasswordHash = passwordHash
+			sl.PasswordHashAlgorithm = impl.Password.AlgorithmName()
+		}
 
 		// Save to our database.
 		if err := impl.ShareableLinkStorer.Create(sessCtx, sl); err != nil {
 			impl.Logger.Error("failed creating shareable link", slog.Any("error", err))
 			return nil, err
 		}
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionCreate,
+			TargetType: auditevent_s.TargetTypeShareableLink,
+			TargetID:   sl.ID,
+			TargetName: sl.SmartFolderName,
+			After:      sl,
+		}
 
 		////
 		//// Exit our transaction successfully.
@@ -147,6 +175,9 @@ func (impl *ShareableLinkControllerImpl) Create(ctx context.Context, req *Sharea
 			slog.Any("error", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, audit); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return result.(*shareablelink_s.ShareableLink), nil
 }
@@ -6,6 +6,11 @@ import (
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 func (impl *ShareableLinkControllerImpl) DeleteByID(ctx context.Context, sfid primitive.ObjectID) error {
@@ -17,13 +22,31 @@ func (impl *ShareableLinkControllerImpl) DeleteByID(ctx context.Context, sfid pr
 	}
 	if shareablelink == nil {
 		impl.Logger.Error("database returns nothing from get by id")
+		return httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+
+	// STEP 2: Enforce tenancy.
+	if err := impl.authorizeShareableLink(ctx, shareablelink, policy.PermissionShare); err != nil {
 		return err
 	}
 
-	// STEP 4: Delete from database.
+	// STEP 3: Delete from database.
+	//
+	// DEVELOPERS NOTE:
+	// The access log of the link is intentionally kept as it is the record
+	// auditors rely on to know who accessed the documents.
 	if err := impl.ShareableLinkStorer.DeleteByID(ctx, sfid); err != nil {
 		impl.Logger.Error("database delete by id error", slog.Any("error", err))
 		return err
 	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionDelete,
+		TargetType: auditevent_s.TargetTypeShareableLink,
+		TargetID:   shareablelink.ID,
+		TargetName: shareablelink.SmartFolderName,
+		Before:     shareablelink,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 	return nil
 }
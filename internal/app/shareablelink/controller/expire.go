@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ExpireOverdue function marks the shareable links of every tenant whose
+// expiry date passed as expired so staff can tell them apart from the links
+// still in use. Visitors were already refused once the expiry date passed.
+func (impl *ShareableLinkControllerImpl) ExpireOverdue(ctx context.Context) (int64, error) {
+	count, err := impl.ShareableLinkStorer.ExpireOverdue(ctx, time.Now())
+	if err != nil {
+		impl.Logger.Error("database expire overdue error", slog.Any("error", err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -2,7 +2,6 @@ package controller
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"log/slog"
@@ -11,7 +10,8 @@ import (
 
 	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
 	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
-	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 )
 
 func (c *ShareableLinkControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error) {
@@ -21,50 +21,80 @@ func (c *ShareableLinkControllerImpl) GetByID(ctx context.Context, id primitive.
 		c.Logger.Error("database get by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if m != nil {
+		if err := c.authorizeShareableLink(ctx, m, policy.PermissionShare); err != nil {
+			return nil, err
+		}
+	}
 	return m, err
 }
 
 type PublicShareableLinkResponseIDO struct {
-	ExpiryDate             time.Time                  `bson:"expiry_date" json:"expiry_date"`
-	ExpiresIn              uint64                     `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
-	SmartFolderID          primitive.ObjectID         `bson:"smart_folder_id" json:"smart_folder_id"`
-	SmartFolderName        string                     `bson:"smart_folder_name" json:"smart_folder_name"`
-	SmartFolderCategory    uint64                     `bson:"smart_folder_category,omitempty" json:"smart_folder_category,omitempty"`
-	SmartFolderSubCategory uint64                     `bson:"smart_folder_sub_category,omitempty" json:"smart_folder_sub_category,omitempty"`
-	SmartFolderDescription string                     `bson:"smart_folder_description" json:"smart_folder_description"`
-	ID                     primitive.ObjectID         `bson:"_id" json:"id"`
-	CreatedAt              time.Time                  `bson:"created_at" json:"created_at"`
-	CreatedByUserID        primitive.ObjectID         `bson:"created_by_user_id" json:"created_by_user_id,omitempty"`
-	CreatedByUserName      string                     `bson:"created_by_user_name" json:"created_by_user_name"`
-	CreatedFromIPAddress   string                     `bson:"created_from_ip_address" json:"created_from_ip_address"`
-	ModifiedAt             time.Time                  `bson:"modified_at" json:"modified_at"`
-	ModifiedByUserID       primitive.ObjectID         `bson:"modified_by_user_id" json:"modified_by_user_id,omitempty"`
-	ModifiedByUserName     string                     `bson:"modified_by_user_name" json:"modified_by_user_name"`
-	ModifiedFromIPAddress  string                     `bson:"modified_from_ip_address" json:"modified_from_ip_address"`
-	TenantID               primitive.ObjectID         `bson:"tenant_id" json:"tenant_id"`
-	TenantName             string                     `bson:"tenant_name" json:"tenant_name"`
-	ObjectFiles            []*objectfile_s.ObjectFile `bson:"object_files" json:"object_files"`
+	ExpiryDate             time.Time                      `bson:"expiry_date" json:"expiry_date"`
+	ExpiresIn              uint64                         `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
+	SmartFolderID          primitive.ObjectID             `bson:"smart_folder_id" json:"smart_folder_id"`
+	SmartFolderName        string                         `bson:"smart_folder_name" json:"smart_folder_name"`
+	SmartFolderCategory    uint64                         `bson:"smart_folder_category,omitempty" json:"smart_folder_category,omitempty"`
+	SmartFolderSubCategory uint64                         `bson:"smart_folder_sub_category,omitempty" json:"smart_folder_sub_category,omitempty"`
+	SmartFolderDescription string                         `bson:"smart_folder_description" json:"smart_folder_description"`
+	ID                     primitive.ObjectID             `bson:"_id" json:"id"`
+	CreatedAt              time.Time                      `bson:"created_at" json:"created_at"`
+	CreatedByUserID        primitive.ObjectID             `bson:"created_by_user_id" json:"created_by_user_id,omitempty"`
+	CreatedByUserName      string                         `bson:"created_by_user_name" json:"created_by_user_name"`
+	CreatedFromIPAddress   string                         `bson:"created_from_ip_address" json:"created_from_ip_address"`
+	ModifiedAt             time.Time                      `bson:"modified_at" json:"modified_at"`
+	ModifiedByUserID       primitive.ObjectID             `bson:"modified_by_user_id" json:"modified_by_user_id,omitempty"`
+	ModifiedByUserName     string                         `bson:"modified_by_user_name" json:"modified_by_user_name"`
+	ModifiedFromIPAddress  string                         `bson:"modified_from_ip_address" json:"modified_from_ip_address"`
+	TenantID               primitive.ObjectID             `bson:"tenant_id" json:"tenant_id"`
+	TenantName             string                         `bson:"tenant_name" json:"tenant_name"`
+	ObjectFiles            []*PublicObjectFileResponseIDO `bson:"object_files" json:"object_files"`
 }
 
-func (c *ShareableLinkControllerImpl) PublicGetByID(ctx context.Context, id primitive.ObjectID) (*PublicShareableLinkResponseIDO, error) {
-	// Retrieve from our database the record for the specific id.
-	sl, err := c.ShareableLinkStorer.GetByID(ctx, id)
-	if err != nil {
-		c.Logger.Error("failed getting shareable link by id",
-			slog.Any("error", err))
-		return nil, err
-	}
+// PublicObjectFileResponseIDO represents the fields of an object file which
+// may be shown to an unauthenticated visitor of a shareable link.
+type PublicObjectFileResponseIDO struct {
+	ID             primitive.ObjectID `bson:"_id" json:"id"`
+	Name           string             `bson:"name" json:"name"`
+	Description    string             `bson:"description" json:"description"`
+	Filename       string             `bson:"filename" json:"filename"`
+	MimeType       string             `bson:"mime_type" json:"mime_type"`
+	ContentType    int8               `bson:"content_type" json:"content_type"`
+	Size           int64              `bson:"size" json:"size"`
+	Classification uint64             `bson:"classification" json:"classification"`
+	UploadedAt     time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+	ModifiedAt     time.Time          `bson:"modified_at" json:"modified_at"`
+}
 
-	// Step 1: Check to see if the `id` exists in our database.
-	if sl == nil {
-		c.Logger.Warn(fmt.Sprintf("shareable link does not exist for id: %s", id.Hex()))
-		return nil, httperror.NewForBadRequestWithSingleField("id", fmt.Sprintf("shareable link does not exist for id: %s", id.Hex()))
+// newPublicObjectFiles function returns the object files visitors may
+// download, that is uploaded files which were not moved to the trash.
+func newPublicObjectFiles(ofof []*objectfile_s.ObjectFile) []*PublicObjectFileResponseIDO {
+	res := make([]*PublicObjectFileResponseIDO, 0, len(ofof))
+	for _, of := range ofof {
+		if of.Status != objectfile_s.StatusActive || of.IsTrashed() {
+			continue
+		}
+		res = append(res, &PublicObjectFileResponseIDO{
+			ID:             of.ID,
+			Name:           of.Name,
+			Description:    of.Description,
+			Filename:       of.Filename,
+			MimeType:       of.MimeType,
+			ContentType:    of.ContentType,
+			Size:           of.Size,
+			Classification: of.Classification,
+			UploadedAt:     of.UploadedAt,
+			ModifiedAt:     of.ModifiedAt,
+		})
 	}
+	return res
+}
 
-	// Step 2: Check to see if the link expired.
-	if time.Now().After(sl.ExpiryDate) {
-		c.Logger.Warn(fmt.Sprintf("shareable link expired at: %s", sl.ExpiryDate))
-		return nil, httperror.NewForBadRequestWithSingleField("id", fmt.Sprintf("shareable link expired at: %s", sl.ExpiryDate))
+func (c *ShareableLinkControllerImpl) PublicGetByID(ctx context.Context, id primitive.ObjectID, password string) (*PublicShareableLinkResponseIDO, error) {
+	// Step 1 & 2: Verify the link is usable by the visitor and count the access.
+	sl, _, err := c.consumePublicAccess(ctx, id, password, primitive.NilObjectID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Step 3: Lookup related objectfiles.
@@ -76,7 +106,12 @@ func (c *ShareableLinkControllerImpl) PublicGetByID(ctx context.Context, id prim
 		return nil, err
 	}
 
-	// Step 4: Return the custom response.
+	// Step 4: Keep a record of this visitor viewing the link.
+	if err := c.recordPublicAccess(ctx, sl, sla_s.TypeView, nil); err != nil {
+		return nil, err
+	}
+
+	// Step 5: Return the custom response.
 	res := &PublicShareableLinkResponseIDO{
 		ExpiryDate:             sl.ExpiryDate,
 		ExpiresIn:              sl.ExpiresIn,
@@ -90,7 +125,7 @@ func (c *ShareableLinkControllerImpl) PublicGetByID(ctx context.Context, id prim
 		ModifiedAt:             sl.ModifiedAt,
 		TenantID:               sl.TenantID,
 		TenantName:             sl.TenantName,
-		ObjectFiles:            ofof,
+		ObjectFiles:            newPublicObjectFiles(ofof),
 	}
 	return res, nil
 }
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+)
+
+func (c *ShareableLinkControllerImpl) ListByFilter(ctx context.Context, f *shareablelink_s.ShareableLinkPaginationListFilter) (*shareablelink_s.ShareableLinkPaginationListResult, error) {
+	// Apply filtering based on ownership and role.
+	f.TenantID = policy.SessionTenantID(ctx) // Manditory
+	if err := policy.Authorize(ctx, policy.PermissionShare); err != nil {
+		return nil, err
+	}
+	if policy.IsRestrictedFromListing(ctx) {
+		userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+		hidden, err := c.SmartFolderStorer.ListIDsHiddenFromUser(ctx, f.TenantID, userID)
+		if err != nil {
+			c.Logger.Error("failed listing hidden smart folders", slog.Any("error", err))
+			return nil, err
+		}
+		f.ExcludeSmartFolderIDs = hidden
+	}
+
+	c.Logger.Debug("listing using filter options:",
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.String("SortField", f.SortField),
+		slog.Int("SortOrder", int(f.SortOrder)),
+		slog.Any("TenantID", f.TenantID),
+		slog.Any("SmartFolderID", f.SmartFolderID),
+		slog.Any("Status", f.Status),
+	)
+
+	m, err := c.ShareableLinkStorer.ListByFilter(ctx, f)
+	if err != nil {
+		c.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, err
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+type ShareableLinkAccessListResponseIDO struct {
+	Results     []*sla_s.ShareableLinkAccess      `json:"results"`
+	NextCursor  primitive.ObjectID                `json:"next_cursor"`
+	HasNextPage bool                              `json:"has_next_page"`
+	Summary     *sla_s.ShareableLinkAccessSummary `json:"summary"`
+}
+
+func (impl *ShareableLinkControllerImpl) ListAccessesByFilter(ctx context.Context, f *sla_s.ShareableLinkAccessListFilter) (*ShareableLinkAccessListResponseIDO, error) {
+	// Only the roles which can share documents are allowed to review who
+	// accessed them.
+	if err := policy.Authorize(ctx, policy.PermissionShare); err != nil {
+		impl.Logger.Warn("you do not have permission to list shareable link accesses")
+		return nil, err
+	}
+
+	// Lookup the shareablelink in our database, else return a `400 Bad Request` error.
+	sl, err := impl.ShareableLinkStorer.GetByID(ctx, f.ShareableLinkID)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if sl == nil {
+		impl.Logger.Warn("shareablelink does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := impl.authorizeShareableLink(ctx, sl, policy.PermissionShare); err != nil {
+		return nil, err
+	}
+	f.TenantID = sl.TenantID // Force tenant tenancy restrictions.
+
+	res, err := impl.ShareableLinkAccessStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+
+	summary, err := impl.ShareableLinkAccessStorer.GetSummaryByShareableLinkID(ctx, sl.ID)
+	if err != nil {
+		impl.Logger.Error("database get summary error", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &ShareableLinkAccessListResponseIDO{
+		Results:     res.Results,
+		NextCursor:  res.NextCursor,
+		HasNextPage: res.HasNextPage,
+		Summary:     summary,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+)
+
+// authorizeShareableLink returns a `403 Forbidden` error if the authenticated
+// user is not granted the permission on the shareable link. Shareable links
+// inherit the access control list of their smart folder.
+func (impl *ShareableLinkControllerImpl) authorizeShareableLink(ctx context.Context, sl *shareablelink_s.ShareableLink, permission int8) error {
+	// Check the tenancy first so other tenants' smart folders are never read.
+	if err := policy.AuthorizeTenant(ctx, sl.TenantID); err != nil {
+		impl.Logger.Warn("shareablelink does not belong to tenant", slog.Any("id", sl.ID))
+		return err
+	}
+	sf, err := impl.SmartFolderStorer.GetByID(ctx, sl.SmartFolderID)
+	if err != nil {
+		impl.Logger.Error("failed getting smart folder", slog.Any("error", err))
+		return err
+	}
+	var acl []*policy.AccessControlEntry
+	if sf != nil {
+		acl = sf.AccessControlList
+	}
+	if err := policy.AuthorizeRecord(ctx, sl.TenantID, acl, permission); err != nil {
+		impl.Logger.Warn("shareablelink access denied", slog.Any("id", sl.ID))
+		return err
+	}
+	return nil
+}
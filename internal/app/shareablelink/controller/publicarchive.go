@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+)
+
+func (c *ShareableLinkControllerImpl) PublicGetArchive(ctx context.Context, id primitive.ObjectID, password string) (*objectfile_c.Archive, error) {
+	// Step 1: Verify the link is usable by the visitor and count the access.
+	sl, _, err := c.consumePublicAccess(ctx, id, password, primitive.NilObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Lookup related objectfiles.
+	ofs, err := c.ObjectFileStorer.ListBySmartFolderID(ctx, sl.SmartFolderID)
+	if err != nil {
+		c.Logger.Error("failed getting object files by smart folder id",
+			slog.Any("smart_folder_id", sl.SmartFolderID),
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	// Step 3: Keep a record of this visitor downloading the entire folder.
+	if err := c.recordPublicAccess(ctx, sl, sla_s.TypeArchiveDownload, nil); err != nil {
+		return nil, err
+	}
+
+	return objectfile_c.NewArchive(c.ObjectStorage, c.Logger, sl.SmartFolderID.Hex(), sl.SmartFolderName, ofs), nil
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"mime"
+	"path/filepath"
+	"time"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+)
+
+// PublicObjectFileContentResponseIDO represents the file content the visitor
+// will download. The caller is responsible for closing the `Content`.
+type PublicObjectFileContentResponseIDO struct {
+	Content     io.ReadCloser
+	Filename    string
+	ContentType string
+}
+
+type PublicObjectFilePresignedURLResponseIDO struct {
+	PresignedURL string    `bson:"presigned_url" json:"presigned_url"`
+	ExpiryDate   time.Time `bson:"expiry_date" json:"expiry_date"`
+}
+
+// publicPresignedURLDuration is intentionally short as the URL gives direct
+// access to the object without any further shareable link checks.
+const publicPresignedURLDuration = 5 * time.Minute
+
+func (c *ShareableLinkControllerImpl) PublicGetObjectFileContent(ctx context.Context, id primitive.ObjectID, objectFileID primitive.ObjectID, password string) (*PublicObjectFileContentResponseIDO, error) {
+	// Step 1: Verify the link and object file are usable by the visitor.
+	sl, of, err := c.consumePublicAccess(ctx, id, password, objectFileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Keep a record of this visitor downloading the object file.
+	if err := c.recordPublicAccess(ctx, sl, sla_s.TypeDownload, of); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Open the content from our object storage.
+	reader, err := c.ObjectStorage.GetBinaryData(ctx, of.ObjectKey)
+	if err != nil {
+		c.Logger.Error("object storage get binary data error",
+			slog.Any("object_file_id", of.ID),
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	// Step 4: Determine the filename and content type.
+	filename := of.Filename
+	if filename == "" {
+		filename = filepath.Base(of.ObjectKey)
+	}
+	contentType := of.MimeType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+	if contentType == "" {
+		// Default content type if not found.
+		contentType = "application/octet-stream"
+	}
+
+	return &PublicObjectFileContentResponseIDO{
+		Content:     reader,
+		Filename:    filename,
+		ContentType: contentType,
+	}, nil
+}
+
+func (c *ShareableLinkControllerImpl) PublicGetObjectFilePresignedURL(ctx context.Context, id primitive.ObjectID, objectFileID primitive.ObjectID, password string) (*PublicObjectFilePresignedURLResponseIDO, error) {
+	// Step 1: Verify the link and object file are usable by the visitor.
+	sl, of, err := c.consumePublicAccess(ctx, id, password, objectFileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Keep a record of this visitor downloading the object file.
+	if err := c.recordPublicAccess(ctx, sl, sla_s.TypeDownload, of); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Generate the short-lived URL.
+	fileURL, err := c.ObjectStorage.GetDownloadablePresignedURL(ctx, of.ObjectKey, publicPresignedURLDuration)
+	if err != nil {
+		c.Logger.Error("object failed get presigned url error",
+			slog.Any("object_file_id", of.ID),
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	return &PublicObjectFilePresignedURLResponseIDO{
+		PresignedURL: fileURL,
+		ExpiryDate:   time.Now().Add(publicPresignedURLDuration),
+	}, nil
+}
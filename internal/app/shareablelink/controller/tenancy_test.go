@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeShareableLinkStorer returns a single shareable link and records which
+// modifying calls were made. Unimplemented methods panic.
+type fakeShareableLinkStorer struct {
+	shareablelink_s.ShareableLinkStorer
+	shareableLink *shareablelink_s.ShareableLink
+	lastFilter    *shareablelink_s.ShareableLinkPaginationListFilter
+	modified      bool
+}
+
+func (s *fakeShareableLinkStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*shareablelink_s.ShareableLink, error) {
+	return s.shareableLink, nil
+}
+
+func (s *fakeShareableLinkStorer) UpdateByID(ctx context.Context, m *shareablelink_s.ShareableLink) error {
+	s.modified = true
+	return nil
+}
+
+func (s *fakeShareableLinkStorer) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	s.modified = true
+	return nil
+}
+
+func (s *fakeShareableLinkStorer) ListByFilter(ctx context.Context, f *shareablelink_s.ShareableLinkPaginationListFilter) (*shareablelink_s.ShareableLinkPaginationListResult, error) {
+	s.lastFilter = f
+	return &shareablelink_s.ShareableLinkPaginationListResult{}, nil
+}
+
+func assertForbidden(t *testing.T, err error) {
+	t.Helper()
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+		t.Errorf("expected forbidden error but received %v", err)
+	}
+}
+
+func TestCrossTenantShareableLinkAccessIsForbidden(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	sl := &shareablelink_s.ShareableLink{ID: primitive.NewObjectID(), TenantID: owner, Status: shareablelink_s.StatusActive}
+
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, other)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, int8(user_s.UserRoleExecutive))
+
+	storer := &fakeShareableLinkStorer{shareableLink: sl}
+	c := &ShareableLinkControllerImpl{
+		Logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ShareableLinkStorer: storer,
+	}
+
+	_, err := c.GetByID(ctx, sl.ID)
+	assertForbidden(t, err)
+
+	_, err = c.RevokeByID(ctx, sl.ID)
+	assertForbidden(t, err)
+
+	_, err = c.ArchiveByID(ctx, sl.ID)
+	assertForbidden(t, err)
+
+	_, err = c.ListAccessesByFilter(ctx, &sla_s.ShareableLinkAccessListFilter{ShareableLinkID: sl.ID})
+	assertForbidden(t, err)
+
+	assertForbidden(t, c.DeleteByID(ctx, sl.ID))
+
+	if storer.modified {
+		t.Error("shareable link of another tenant was modified")
+	}
+}
+
+func TestShareableLinkListIsScopedToSessionTenant(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, owner)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, int8(user_s.UserRoleExecutive))
+
+	storer := &fakeShareableLinkStorer{}
+	c := &ShareableLinkControllerImpl{
+		Logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ShareableLinkStorer: storer,
+	}
+
+	if _, err := c.ListByFilter(ctx, &shareablelink_s.ShareableLinkPaginationListFilter{TenantID: other}); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if storer.lastFilter.TenantID != owner {
+		t.Errorf("expected list to be scoped to %v but was %v", owner, storer.lastFilter.TenantID)
+	}
+}
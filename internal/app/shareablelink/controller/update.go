@@ -9,34 +9,28 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
 type ShareableLinkUpdateRequestIDO struct {
-	ID          primitive.ObjectID `bson:"id" json:"id"`
-	Description string             `bson:"description" json:"description"`
-	Name        string             `bson:"name" json:"name"`
-	Category    uint64             `bson:"category,omitempty" json:"category,omitempty"`
-	SubCategory uint64             `bson:"sub_category,omitempty" json:"sub_category,omitempty"`
-	SortNumber  int8               `bson:"sort_number" json:"sort_number"`
+	ID             primitive.ObjectID `bson:"id" json:"id"`
+	ExpiresIn      uint64             `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
+	MaxAccessCount *uint64            `bson:"max_access_count,omitempty" json:"max_access_count,omitempty"` // Optional, zero removes the limit.
 }
 
 func (impl *ShareableLinkControllerImpl) validateUpdateRequest(ctx context.Context, dirtyData *ShareableLinkUpdateRequestIDO) error {
 	e := make(map[string]string)
 
-	if dirtyData.Name == "" {
-		e["name"] = "missing value"
+	if dirtyData.ID.IsZero() {
+		e["id"] = "missing value"
 	}
-	if dirtyData.Category == 0 {
-		e["category"] = "missing value"
-	}
-	if dirtyData.SubCategory == 0 {
-		e["sub_category"] = "missing value"
-	}
-	if dirtyData.SortNumber == 0 {
-		e["sort_number"] = "missing value"
+	if dirtyData.ExpiresIn == 0 {
+		e["expires_in"] = "missing value"
 	}
 
 	if len(e) != 0 {
@@ -45,6 +39,8 @@ func (impl *ShareableLinkControllerImpl) validateUpdateRequest(ctx context.Conte
 	return nil
 }
 
+// UpdateByID function extends the expiry of the shareable link by `expires_in`
+// hours starting from now and optionally changes the maximum access count.
 func (impl *ShareableLinkControllerImpl) UpdateByID(ctx context.Context, requestData *ShareableLinkUpdateRequestIDO) (*shareablelink_s.ShareableLink, error) {
 	//
 	// Perform our validation and return validation error on any issues detected.
@@ -55,24 +51,14 @@ func (impl *ShareableLinkControllerImpl) UpdateByID(ctx context.Context, request
 		return nil, err
 	}
 
+	//
 	// Get variables from our user authenticated session.
 	//
 
-	tid, _ := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
-	tn, _ := ctx.Value(constants.SessionUserTenantName).(string)
-	// role, _ := ctx.Value(constants.SessionUserRole).(int8)
 	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
 	userName, _ := ctx.Value(constants.SessionUserName).(string)
 	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
 
-	// switch role {
-	// case u_s.UserRoleExecutive, u_s.UserRoleManagement, u_s.UserRoleFrontlineStaff:
-	// 	break
-	// default:
-	// 	impl.Logger.Error("you do not have permission to create a client")
-	// 	return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission to create a client")
-	// }
-
 	////
 	//// Start the transaction.
 	////
@@ -85,6 +71,9 @@ func (impl *ShareableLinkControllerImpl) UpdateByID(ctx context.Context, request
 	}
 	defer session.EndSession(ctx)
 
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 
@@ -93,51 +82,63 @@ func (impl *ShareableLinkControllerImpl) UpdateByID(ctx context.Context, request
 		////
 
 		// Lookup the shareablelink in our database, else return a `400 Bad Request` error.
-		hh, err := impl.ShareableLinkStorer.GetByID(sessCtx, requestData.ID)
+		sl, err := impl.ShareableLinkStorer.GetByID(sessCtx, requestData.ID)
 		if err != nil {
 			impl.Logger.Error("database error", slog.Any("err", err))
 			return nil, err
 		}
-		if hh == nil {
+		if sl == nil {
 			impl.Logger.Warn("shareablelink does not exist validation error")
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
+		if err := impl.authorizeShareableLink(ctx, sl, policy.PermissionShare); err != nil {
+			return nil, err
+		}
+
+		// Revoked or archived links cannot be brought back to life; staff
+		// must generate a new link instead. Expired links may be extended.
+		if sl.Status != shareablelink_s.StatusActive && sl.Status != shareablelink_s.StatusExpired {
+			impl.Logger.Warn("shareablelink is not active validation error")
+			return nil, httperror.NewForBadRequestWithSingleField("id", "shareable link is no longer active")
+		}
+		before := auditevent_c.Snapshot(sl)
 
 		////
 		//// Update primary record.
 		////
 
 		// Base
-		hh.TenantID = tid
-		hh.TenantName = tn
-		hh.ModifiedAt = time.Now()
-		hh.ModifiedByUserID = userID
-		hh.ModifiedByUserName = userName
-		hh.ModifiedFromIPAddress = ipAddress
+		sl.ModifiedAt = time.Now()
+		sl.ModifiedByUserID = userID
+		sl.ModifiedByUserName = userName
+		sl.ModifiedFromIPAddress = ipAddress
 
 		// Content
-		hh.Name = requestData.Name
-		hh.Description = requestData.Description
-		hh.Category = requestData.Category
-		hh.SubCategory = requestData.SubCategory
-		hh.SortNumber = requestData.SortNumber
+		sl.ExpiryDate = time.Now().Add(time.Duration(requestData.ExpiresIn) * time.Hour)
+		sl.ExpiresIn = requestData.ExpiresIn
+		if requestData.MaxAccessCount != nil {
+			sl.MaxAccessCount = *requestData.MaxAccessCount
+		}
+		sl.Status = shareablelink_s.StatusActive
 
-		if err := impl.ShareableLinkStorer.UpdateByID(sessCtx, hh); err != nil {
+		if err := impl.ShareableLinkStorer.UpdateByID(sessCtx, sl); err != nil {
 			impl.Logger.Error("shareablelink update by id error", slog.Any("error", err))
 			return nil, err
 		}
-
-		////
-		//// Update related records.
-		////
-
-		//
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionUpdate,
+			TargetType: auditevent_s.TargetTypeShareableLink,
+			TargetID:   sl.ID,
+			TargetName: sl.SmartFolderName,
+			Before:     before,
+			After:      sl,
+		}
 
 		////
 		//// Exit our transaction successfully.
 		////
 
-		return hh, nil
+		return sl, nil
 	}
 
 	// Start a transaction
@@ -147,6 +148,9 @@ func (impl *ShareableLinkControllerImpl) UpdateByID(ctx context.Context, request
 			slog.Any("error", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, audit); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return result.(*shareablelink_s.ShareableLink), nil
 }
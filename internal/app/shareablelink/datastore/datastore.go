@@ -16,6 +16,8 @@ import (
 const (
 	StatusActive   = 1
 	StatusArchived = 2
+	StatusRevoked  = 3
+	StatusExpired  = 4
 
 	CategoryUnspecified      = 1
 	CategoryGovernmentCanada = 2
@@ -42,6 +44,22 @@ type ShareableLink struct {
 	ModifiedFromIPAddress  string             `bson:"modified_from_ip_address" json:"modified_from_ip_address"`
 	TenantID               primitive.ObjectID `bson:"tenant_id" json:"tenant_id"`
 	TenantName             string             `bson:"tenant_name" json:"tenant_name"`
+
+	// IsPasswordProtected indicates the visitor must submit a passphrase
+	// which matches `PasswordHash` before any content is returned.
+	IsPasswordProtected   bool   `bson:"is_password_protected" json:"is_password_protected"`
+	PasswordHashAlgorithm string `bson:"password_hash_algorithm" json:"-"`
+	PasswordHash          string `bson:"password_hash" json:"-"`
+
+	// MaxAccessCount limits how many times the link can be viewed or used
+	// to download content; a value of zero means unlimited.
+	MaxAccessCount uint64 `bson:"max_access_count,omitempty" json:"max_access_count,omitempty"`
+	AccessCount    uint64 `bson:"access_count" json:"access_count"`
+
+	RevokedAt            time.Time          `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	RevokedByUserID      primitive.ObjectID `bson:"revoked_by_user_id,omitempty" json:"revoked_by_user_id,omitempty"`
+	RevokedByUserName    string             `bson:"revoked_by_user_name,omitempty" json:"revoked_by_user_name,omitempty"`
+	RevokedFromIPAddress string             `bson:"revoked_from_ip_address,omitempty" json:"revoked_from_ip_address,omitempty"`
 }
 
 type ShareableLinkListResult struct {
@@ -65,10 +83,12 @@ type ShareableLinkStorer interface {
 	GetLatestByTenantID(ctx context.Context, tenantID primitive.ObjectID) (*ShareableLink, error)
 	CheckIfExistsByEmail(ctx context.Context, email string) (bool, error)
 	UpdateByID(ctx context.Context, m *ShareableLink) error
+	IncrementAccessCountByID(ctx context.Context, id primitive.ObjectID) (bool, error)
 	ListByFilter(ctx context.Context, f *ShareableLinkPaginationListFilter) (*ShareableLinkPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *ShareableLinkPaginationListFilter) ([]*ShareableLinkAsSelectOption, error)
 	ListByTenantID(ctx context.Context, tid primitive.ObjectID) (*ShareableLinkPaginationListResult, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	ExpireOverdue(ctx context.Context, now time.Time) (int64, error)
 }
 
 type ShareableLinkStorerImpl struct {
@@ -83,6 +103,7 @@ func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) Sh
 
 	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
 		{Keys: bson.D{{Key: "tenant_id", Value: 1}}},
+		{Keys: bson.D{{Key: "smart_folder_id", Value: 1}}},
 		{Keys: bson.D{{Key: "public_id", Value: -1}}},
 		{Keys: bson.D{{Key: "created_at", Value: -1}}},
 		{Keys: bson.D{{Key: "expiry_date", Value: -1}}},
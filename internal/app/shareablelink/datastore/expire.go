@@ -0,0 +1,32 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ExpireOverdue function marks every active shareable link, of every tenant,
+// whose expiry date passed as expired. Returns the number of links expired.
+func (impl ShareableLinkStorerImpl) ExpireOverdue(ctx context.Context, now time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":      StatusActive,
+		"expiry_date": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      StatusExpired,
+			"modified_at": now,
+		},
+	}
+
+	res, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
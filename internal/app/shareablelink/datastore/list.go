@@ -9,7 +9,7 @@ import (
 )
 
 func (impl ShareableLinkStorerImpl) ListByFilter(ctx context.Context, f *ShareableLinkPaginationListFilter) (*ShareableLinkPaginationListResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Create the paginated filter based on the cursor
@@ -22,6 +22,15 @@ func (impl ShareableLinkStorerImpl) ListByFilter(ctx context.Context, f *Shareab
 	if !f.TenantID.IsZero() {
 		filter["tenant_id"] = f.TenantID
 	}
+	if len(f.ExcludeSmartFolderIDs) > 0 {
+		cond := bson.M{"$nin": f.ExcludeSmartFolderIDs}
+		if !f.SmartFolderID.IsZero() {
+			cond["$eq"] = f.SmartFolderID
+		}
+		filter["smart_folder_id"] = cond
+	} else if !f.SmartFolderID.IsZero() {
+		filter["smart_folder_id"] = f.SmartFolderID
+	}
 
 	// if f.ExcludeArchived {
 	// 	filter["status"] = bson.M{"$ne": ShareableLinkStatusArchived} // Do not list archived items! This code
@@ -10,7 +10,7 @@ import (
 )
 
 func (impl ShareableLinkStorerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *ShareableLinkPaginationListFilter) ([]*ShareableLinkAsSelectOption, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Get a reference to the collection
@@ -28,6 +28,9 @@ func (impl ShareableLinkStorerImpl) ListAsSelectOptionByFilter(ctx context.Conte
 	if !f.TenantID.IsZero() {
 		query["tenant_id"] = f.TenantID
 	}
+	if !f.SmartFolderID.IsZero() {
+		query["smart_folder_id"] = f.SmartFolderID
+	}
 
 	if startAfter != "" {
 		// Find the document with the given startAfter ID
@@ -24,9 +24,14 @@ type ShareableLinkPaginationListFilter struct {
 	SortOrder int8 // 1=ascending | -1=descending
 
 	// Filter related.
-	TenantID   primitive.ObjectID
-	Status     int8
-	SearchText string
+	TenantID      primitive.ObjectID
+	SmartFolderID primitive.ObjectID
+	Status        int8
+	SearchText    string
+
+	// ExcludeSmartFolderIDs hides the links of the smart folders which the
+	// authenticated user was not granted access to.
+	ExcludeSmartFolderIDs []primitive.ObjectID
 }
 
 // ShareableLinkPaginationListResult represents the paginated list results for
@@ -10,7 +10,7 @@ func (impl ShareableLinkStorerImpl) ListByTenantID(ctx context.Context, tid prim
 	f := &ShareableLinkPaginationListFilter{
 		Cursor:    "",
 		PageSize:  1_000_000_000, // Unlimited
-		SortField: "sort_number",
+		SortField: "created_at",
 		SortOrder: 1,
 		TenantID:  tid,
 		Status:    StatusActive,
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (impl ShareableLinkStorerImpl) UpdateByID(ctx context.Context, m *ShareableLink) error {
@@ -23,3 +24,27 @@ func (impl ShareableLinkStorerImpl) UpdateByID(ctx context.Context, m *Shareable
 
 	return nil
 }
+
+// IncrementAccessCountByID function counts one access of the shareable link
+// unless it reached its maximum access count. The check and the increment are
+// a single update so concurrent visitors on every node cannot exceed the
+// maximum. Returns false if the access was not counted.
+func (impl ShareableLinkStorerImpl) IncrementAccessCountByID(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			// A missing or zero maximum means the link has no limit.
+			bson.M{"max_access_count": bson.M{"$in": bson.A{0, nil}}},
+			bson.M{"$expr": bson.M{"$lt": bson.A{"$access_count", "$max_access_count"}}},
+		},
+	}
+	update := bson.M{
+		"$inc": bson.M{"access_count": 1},
+	}
+	res, err := impl.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database increment access count by id error", slog.Any("error", err))
+		return false, err
+	}
+	return res.MatchedCount == 1, nil
+}
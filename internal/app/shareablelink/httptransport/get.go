@@ -39,7 +39,11 @@ func (h *Handler) PublicGetByID(w http.ResponseWriter, r *http.Request, id strin
 		return
 	}
 
-	res, err := h.Controller.PublicGetByID(ctx, objectID)
+	// The optional passphrase is submitted through a header so it never
+	// appears in the URL or access logs.
+	password := r.Header.Get(PasswordHeader)
+
+	res, err := h.Controller.PublicGetByID(ctx, objectID, password)
 	if err != nil {
 		httperror.ResponseError(w, err)
 		return
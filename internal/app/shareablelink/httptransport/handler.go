@@ -6,6 +6,10 @@ import (
 	shareablelink_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/controller"
 )
 
+// PasswordHeader is the request header used by visitors to submit the
+// passphrase of a password protected shareable link.
+const PasswordHeader = "X-Shareable-Link-Password"
+
 // Handler Creates http request handler
 type Handler struct {
 	Logger     *slog.Logger
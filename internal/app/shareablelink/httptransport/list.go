@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
@@ -15,8 +17,8 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 	f := &shareablelink_s.ShareableLinkPaginationListFilter{
 		Cursor:    "",
 		PageSize:  25,
-		SortField: "sort_number",
-		SortOrder: 1, // 1=ascending | -1=descending
+		SortField: "created_at",
+		SortOrder: shareablelink_s.OrderDescending,
 	}
 
 	// Here is where you extract url parameters.
@@ -36,11 +38,6 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 		f.PageSize = pageSize
 	}
 
-	sortField := query.Get("sort_field")
-	if sortField != "" {
-		f.SortField = sortField
-	}
-
 	sortOrderStr := query.Get("sort_order")
 	if sortOrderStr != "" {
 		sortOrder, _ := strconv.ParseInt(sortOrderStr, 10, 64)
@@ -50,9 +47,20 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 		f.SortOrder = int8(sortOrder)
 	}
 
-	searchKeyword := query.Get("search")
-	if searchKeyword != "" {
-		f.SearchText = searchKeyword
+	sfidstr := query.Get("smart_folder_id")
+	if sfidstr != "" {
+		smartFolderID, err := primitive.ObjectIDFromHex(sfidstr)
+		if err != nil {
+			httperror.ResponseError(w, err)
+			return
+		}
+		f.SmartFolderID = smartFolderID
+	}
+
+	statusStr := query.Get("status")
+	if statusStr != "" {
+		status, _ := strconv.ParseInt(statusStr, 10, 64)
+		f.Status = int8(status)
 	}
 
 	m, err := h.Controller.ListByFilter(ctx, f)
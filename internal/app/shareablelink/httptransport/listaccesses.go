@@ -0,0 +1,67 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	sla_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) ListAccessesByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	f := &sla_s.ShareableLinkAccessListFilter{
+		Cursor:          primitive.NilObjectID,
+		PageSize:        25,
+		ShareableLinkID: objectID,
+	}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	cursor := query.Get("cursor")
+	if cursor != "" {
+		cursor, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			httperror.ResponseError(w, err)
+			return
+		}
+		f.Cursor = cursor
+	}
+
+	pageSize := query.Get("page_size")
+	if pageSize != "" {
+		pageSize, _ := strconv.ParseInt(pageSize, 10, 64)
+		if pageSize <= 0 || pageSize > 250 {
+			pageSize = 250
+		}
+		f.PageSize = pageSize
+	}
+
+	accessType := query.Get("type")
+	if accessType != "" {
+		accessType, _ := strconv.ParseInt(accessType, 10, 8)
+		f.Type = int8(accessType)
+	}
+
+	res, err := h.Controller.ListAccessesByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
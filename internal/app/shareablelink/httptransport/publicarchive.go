@@ -0,0 +1,38 @@
+package httptransport
+
+import (
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) PublicGetArchive(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	archive, err := h.Controller.PublicGetArchive(ctx, objectID, r.Header.Get(PasswordHeader))
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	// Set content headers for file download.
+	w.Header().Set("Content-Disposition", "attachment; filename="+archive.Filename)
+	w.Header().Set("Content-Type", "application/zip")
+
+	// DEVELOPERS NOTE:
+	// Once streaming starts the status code was already sent so we can only
+	// log any errors that happen afterwards.
+	if err := archive.Write(ctx, w); err != nil {
+		h.Logger.Error("failed streaming archive", slog.Any("error", err))
+		return
+	}
+}
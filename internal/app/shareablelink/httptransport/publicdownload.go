@@ -0,0 +1,70 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) PublicGetObjectFileContent(w http.ResponseWriter, r *http.Request, id string, objectFileID string) {
+	ctx := r.Context()
+
+	slid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid value"))
+		return
+	}
+	ofid, err := primitive.ObjectIDFromHex(objectFileID)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("object_file_id", "invalid value"))
+		return
+	}
+
+	res, err := h.Controller.PublicGetObjectFileContent(ctx, slid, ofid, r.Header.Get(PasswordHeader))
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	defer res.Content.Close()
+
+	// Set content headers for file download.
+	w.Header().Set("Content-Disposition", "attachment; filename="+res.Filename)
+	w.Header().Set("Content-Type", res.ContentType)
+
+	// Stream the file content to the response body.
+	if _, err := io.Copy(w, res.Content); err != nil {
+		h.Logger.Error("failed streaming file content to response", slog.Any("error", err))
+		return
+	}
+}
+
+func (h *Handler) PublicGetObjectFilePresignedURL(w http.ResponseWriter, r *http.Request, id string, objectFileID string) {
+	ctx := r.Context()
+
+	slid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("id", "invalid value"))
+		return
+	}
+	ofid, err := primitive.ObjectIDFromHex(objectFileID)
+	if err != nil {
+		httperror.ResponseError(w, httperror.NewForBadRequestWithSingleField("object_file_id", "invalid value"))
+		return
+	}
+
+	res, err := h.Controller.PublicGetObjectFilePresignedURL(ctx, slid, ofid, r.Header.Get(PasswordHeader))
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
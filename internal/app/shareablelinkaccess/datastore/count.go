@@ -0,0 +1,30 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CountByIPAddressSince function returns the number of accesses of the type
+// made through the shareable link from the IP address since the time.
+func (impl ShareableLinkAccessStorerImpl) CountByIPAddressSince(ctx context.Context, shareableLinkID primitive.ObjectID, accessType int8, ipAddress string, since time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"shareable_link_id": shareableLinkID,
+		"type":              accessType,
+		"ip_address":        ipAddress,
+		"created_at":        bson.M{"$gte": since},
+	}
+	count, err := impl.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database count by ip address since error", slog.Any("error", err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,22 @@
+package datastore
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl ShareableLinkAccessStorerImpl) Create(ctx context.Context, m *ShareableLinkAccess) error {
+	if m.ID == primitive.NilObjectID {
+		m.ID = primitive.NewObjectID()
+		impl.Logger.Warn("database insert shareable link access not included id value, created id now.", slog.Any("id", m.ID))
+	}
+
+	if _, err := impl.Collection.InsertOne(ctx, m); err != nil {
+		impl.Logger.Error("database insert error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+const (
+	TypeView            = 1
+	TypeDownload        = 2
+	TypeArchiveDownload = 3
+
+	// TypeFailedPassword is a visitor entering the wrong passphrase, it is
+	// not counted as an access.
+	TypeFailedPassword = 4
+)
+
+// ShareableLinkAccess represents a single hit on a public shareable link by
+// an unauthenticated visitor. Records are append-only.
+type ShareableLinkAccess struct {
+	ID              primitive.ObjectID `bson:"_id" json:"id"`
+	ShareableLinkID primitive.ObjectID `bson:"shareable_link_id" json:"shareable_link_id"`
+	SmartFolderID   primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
+	Type            int8               `bson:"type" json:"type"`
+	ObjectFileID    primitive.ObjectID `bson:"object_file_id,omitempty" json:"object_file_id,omitempty"`
+	ObjectFileName  string             `bson:"object_file_name,omitempty" json:"object_file_name,omitempty"`
+	IPAddress       string             `bson:"ip_address" json:"ip_address"`
+	UserAgent       string             `bson:"user_agent" json:"user_agent"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	TenantID        primitive.ObjectID `bson:"tenant_id" json:"tenant_id"`
+}
+
+type ShareableLinkAccessListFilter struct {
+	// Pagination related.
+	Cursor   primitive.ObjectID
+	PageSize int64
+
+	// Filter related.
+	TenantID        primitive.ObjectID
+	ShareableLinkID primitive.ObjectID
+	Type            int8
+}
+
+type ShareableLinkAccessListResult struct {
+	Results     []*ShareableLinkAccess `json:"results"`
+	NextCursor  primitive.ObjectID     `json:"next_cursor"`
+	HasNextPage bool                   `json:"has_next_page"`
+}
+
+// ShareableLinkAccessSummary represents the aggregate counts of all the
+// accesses made through a particular shareable link.
+type ShareableLinkAccessSummary struct {
+	TotalCount           int64     `bson:"total_count" json:"total_count"`
+	ViewCount            int64     `bson:"view_count" json:"view_count"`
+	DownloadCount        int64     `bson:"download_count" json:"download_count"`
+	ArchiveDownloadCount int64     `bson:"archive_download_count" json:"archive_download_count"`
+	FailedPasswordCount  int64     `bson:"failed_password_count" json:"failed_password_count"`
+	UniqueIPAddressCount int64     `bson:"unique_ip_address_count" json:"unique_ip_address_count"`
+	FirstAccessedAt      time.Time `bson:"first_accessed_at,omitempty" json:"first_accessed_at,omitempty"`
+	LastAccessedAt       time.Time `bson:"last_accessed_at,omitempty" json:"last_accessed_at,omitempty"`
+}
+
+// ShareableLinkAccessStorer Interface for shareable link access.
+type ShareableLinkAccessStorer interface {
+	Create(ctx context.Context, m *ShareableLinkAccess) error
+	ListByFilter(ctx context.Context, f *ShareableLinkAccessListFilter) (*ShareableLinkAccessListResult, error)
+	GetSummaryByShareableLinkID(ctx context.Context, shareableLinkID primitive.ObjectID) (*ShareableLinkAccessSummary, error)
+	CountByIPAddressSince(ctx context.Context, shareableLinkID primitive.ObjectID, accessType int8, ipAddress string, since time.Time) (int64, error)
+}
+
+type ShareableLinkAccessStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) ShareableLinkAccessStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("shareable_link_accesses")
+
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}}},
+		{Keys: bson.D{{Key: "shareable_link_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "shareable_link_id", Value: 1}, {Key: "ip_address", Value: 1}, {Key: "type", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &ShareableLinkAccessStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (impl ShareableLinkAccessStorerImpl) ListByFilter(ctx context.Context, f *ShareableLinkAccessListFilter) (*ShareableLinkAccessListResult, error) {
+	// Create the filter based on the cursor. Accesses are always listed
+	// newest first so the cursor moves backwards through the `_id` values.
+	filter := bson.M{}
+	if !f.Cursor.IsZero() {
+		filter["_id"] = bson.M{"$lt": f.Cursor}
+	}
+
+	// Add filter conditions to the filter
+	if !f.TenantID.IsZero() {
+		filter["tenant_id"] = f.TenantID
+	}
+	if !f.ShareableLinkID.IsZero() {
+		filter["shareable_link_id"] = f.ShareableLinkID
+	}
+	if f.Type != 0 {
+		filter["type"] = f.Type
+	}
+
+	impl.Logger.Debug("fetching shareable link accesses list",
+		slog.Any("Cursor", f.Cursor),
+		slog.Int64("PageSize", f.PageSize),
+		slog.Any("TenantID", f.TenantID),
+		slog.Any("ShareableLinkID", f.ShareableLinkID),
+		slog.Any("Type", f.Type),
+	)
+
+	// A page must hold at least one record else the page cannot be cut below.
+	pageSize := f.PageSize
+	if pageSize <= 0 {
+		pageSize = 25
+	}
+
+	// Fetch one more record then requested so we know if there is a next page.
+	options := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(pageSize + 1)
+
+	// Execute the query
+	cursor, err := impl.Collection.Find(ctx, filter, options)
+	if err != nil {
+		impl.Logger.Error("database find error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []*ShareableLinkAccess{}
+	if err := cursor.All(ctx, &results); err != nil {
+		impl.Logger.Error("database cursor decode error", slog.Any("error", err))
+		return nil, err
+	}
+
+	hasNextPage := false
+	nextCursor := primitive.NilObjectID
+	if int64(len(results)) > pageSize {
+		hasNextPage = true
+		results = results[:pageSize]
+		nextCursor = results[len(results)-1].ID
+	}
+
+	return &ShareableLinkAccessListResult{
+		Results:     results,
+		NextCursor:  nextCursor,
+		HasNextPage: hasNextPage,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl ShareableLinkAccessStorerImpl) GetSummaryByShareableLinkID(ctx context.Context, shareableLinkID primitive.ObjectID) (*ShareableLinkAccessSummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"shareable_link_id": shareableLinkID,
+			"type":              bson.M{"$ne": TypeFailedPassword},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         nil,
+			"total_count": bson.M{"$sum": 1},
+			"view_count": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$type", TypeView}}, 1, 0},
+			}},
+			"download_count": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$type", TypeDownload}}, 1, 0},
+			}},
+			"archive_download_count": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$type", TypeArchiveDownload}}, 1, 0},
+			}},
+			"ip_addresses":      bson.M{"$addToSet": "$ip_address"},
+			"first_accessed_at": bson.M{"$min": "$created_at"},
+			"last_accessed_at":  bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                     0,
+			"total_count":             1,
+			"view_count":              1,
+			"download_count":          1,
+			"archive_download_count":  1,
+			"unique_ip_address_count": bson.M{"$size": "$ip_addresses"},
+			"first_accessed_at":       1,
+			"last_accessed_at":        1,
+		}}},
+	}
+
+	cursor, err := impl.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		impl.Logger.Error("database aggregate error", slog.Any("error", err))
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	// If no accesses were made then return an empty summary.
+	summary := &ShareableLinkAccessSummary{}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(summary); err != nil {
+			impl.Logger.Error("database decode error", slog.Any("error", err))
+			return nil, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	// Failed passphrase attempts are not accesses so count them separately.
+	summary.FailedPasswordCount, err = impl.Collection.CountDocuments(ctx, bson.M{
+		"shareable_link_id": shareableLinkID,
+		"type":              TypeFailedPassword,
+	})
+	if err != nil {
+		impl.Logger.Error("database count error", slog.Any("error", err))
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// prepareAccessControlList function will verify the access control list
+// submitted by the authenticated user and return it with the name of every
+// user filled in from the database.
+func (impl *SmartFolderControllerImpl) prepareAccessControlList(ctx context.Context, acl []*policy.AccessControlEntry) ([]*policy.AccessControlEntry, error) {
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	if !policy.CanManageAccessControl(role) {
+		impl.Logger.Warn("authenticated user cannot manage access control lists", slog.Any("role", role))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "your role does not grant you access to manage the access control list")
+	}
+
+	e := make(map[string]string)
+	seen := make(map[primitive.ObjectID]bool, len(acl))
+	res := make([]*policy.AccessControlEntry, 0, len(acl))
+	for i, entry := range acl {
+		field := fmt.Sprintf("access_control_list[%d]", i)
+		if entry == nil || entry.UserID.IsZero() {
+			e[field] = "missing user"
+			continue
+		}
+		if seen[entry.UserID] {
+			e[field] = "user is listed more than once"
+			continue
+		}
+		seen[entry.UserID] = true
+		for _, p := range entry.Permissions {
+			if !policy.IsValidPermission(p) {
+				e[field] = fmt.Sprintf("invalid permission %d", p)
+			}
+		}
+
+		u, err := impl.UserStorer.GetByID(ctx, entry.UserID)
+		if err != nil {
+			impl.Logger.Error("database get by id error", slog.Any("error", err))
+			return nil, err
+		}
+		if u == nil || !policy.CanAccessTenant(ctx, u.TenantID) {
+			e[field] = "user does not exist"
+			continue
+		}
+		res = append(res, &policy.AccessControlEntry{
+			UserID:      u.ID,
+			UserName:    u.Name,
+			Permissions: entry.Permissions,
+		})
+	}
+	if len(e) != 0 {
+		return nil, httperror.NewForBadRequest(&e)
+	}
+	return res, nil
+}
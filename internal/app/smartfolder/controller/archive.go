@@ -7,7 +7,10 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
@@ -25,12 +28,30 @@ func (impl *SmartFolderControllerImpl) ArchiveByID(ctx context.Context, id primi
 		impl.Logger.Warn("smartfolder does not exist validation error")
 		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 	}
+	if err := policy.AuthorizeRecord(ctx, ou.TenantID, ou.AccessControlList, policy.PermissionEdit); err != nil {
+		impl.Logger.Warn("smartfolder access denied", slog.Any("id", id))
+		return nil, err
+	}
+	if err := requireNotTrashed(ou); err != nil {
+		return nil, err
+	}
 
+	before := auditevent_c.Snapshot(ou)
 	ou.Status = smartfolder_s.StatusArchived
 
 	if err := impl.SmartFolderStorer.UpdateByID(ctx, ou); err != nil {
 		impl.Logger.Error("smartfolder update by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionArchive,
+		TargetType: auditevent_s.TargetTypeSmartFolder,
+		TargetID:   ou.ID,
+		TargetName: ou.Name,
+		Before:     before,
+		After:      ou,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 	return ou, nil
 }
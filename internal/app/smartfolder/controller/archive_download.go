@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (impl *SmartFolderControllerImpl) GetArchiveByID(ctx context.Context, id primitive.ObjectID) (*objectfile_c.Archive, error) {
+	// Lookup the smartfolder in our database, else return a `400 Bad Request` error.
+	sf, err := impl.SmartFolderStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if sf == nil {
+		impl.Logger.Warn("smartfolder does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := policy.AuthorizeRecord(ctx, sf.TenantID, sf.AccessControlList, policy.PermissionView); err != nil {
+		impl.Logger.Warn("smartfolder access denied", slog.Any("id", id))
+		return nil, err
+	}
+	if err := requireNotTrashed(sf); err != nil {
+		return nil, err
+	}
+
+	// Lookup related objectfiles.
+	ofs, err := impl.ObjectFileStorer.ListBySmartFolderID(ctx, sf.ID)
+	if err != nil {
+		impl.Logger.Error("failed getting object files by smart folder id",
+			slog.Any("smart_folder_id", sf.ID),
+			slog.Any("error", err))
+		return nil, err
+	}
+
+	return objectfile_c.NewArchive(impl.ObjectStorage, impl.Logger, sf.ID.Hex(), sf.Name, ofs), nil
+}
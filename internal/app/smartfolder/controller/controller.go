@@ -9,8 +9,13 @@ import (
 
 	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/templatedemailer"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
 	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	shareablelink_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/controller"
+	shareablelink_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/kmutex"
@@ -29,20 +34,29 @@ type SmartFolderController interface {
 	ArchiveByID(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 	GenerateShareableLink(ctx context.Context, requestData *GenerateShareableLinkRequestIDO) (*GenerateShareableLinkResponseIDO, error)
+	GetArchiveByID(ctx context.Context, id primitive.ObjectID) (*objectfile_c.Archive, error)
+	ListTrashByFilter(ctx context.Context, f *smartfolder_s.SmartFolderPaginationListFilter) (*smartfolder_s.SmartFolderPaginationListResult, error)
+	RestoreByID(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error)
+	PurgeByID(ctx context.Context, id primitive.ObjectID) error
+	PurgeExpiredTrash(ctx context.Context) (int, error)
 }
 
 type SmartFolderControllerImpl struct {
-	Config            *config.Conf
-	Logger            *slog.Logger
-	UUID              uuid.Provider
-	ObjectStorage     object_storage.ObjectStorager
-	Password          password.Provider
-	Kmutex            kmutex.Provider
-	DbClient          *mongo.Client
-	UserStorer        user_s.UserStorer
-	SmartFolderStorer smartfolder_s.SmartFolderStorer
-	ObjectFileStorer  objectfile_s.ObjectFileStorer
-	TemplatedEmailer  templatedemailer.TemplatedEmailer
+	Config              *config.Conf
+	Logger              *slog.Logger
+	UUID                uuid.Provider
+	ObjectStorage       object_storage.ObjectStorager
+	Password            password.Provider
+	Kmutex              kmutex.Provider
+	DbClient            *mongo.Client
+	UserStorer          user_s.UserStorer
+	SmartFolderStorer   smartfolder_s.SmartFolderStorer
+	ObjectFileStorer    objectfile_s.ObjectFileStorer
+	ShareableLinkStorer shareablelink_s.ShareableLinkStorer
+	TenantStorer        tenant_s.TenantStorer
+	TemplatedEmailer    templatedemailer.TemplatedEmailer
+	AuditEvent          auditevent_c.AuditEventController
+	ShareableLink       shareablelink_c.ShareableLinkController
 }
 
 func NewController(
@@ -57,19 +71,27 @@ func NewController(
 	usr_storer user_s.UserStorer,
 	smartfolder_s smartfolder_s.SmartFolderStorer,
 	obj_storer objectfile_s.ObjectFileStorer,
+	sl_storer shareablelink_s.ShareableLinkStorer,
+	tenant_storer tenant_s.TenantStorer,
+	ae_controller auditevent_c.AuditEventController,
+	sl_controller shareablelink_c.ShareableLinkController,
 ) SmartFolderController {
 	s := &SmartFolderControllerImpl{
-		Config:            appCfg,
-		Logger:            loggerp,
-		UUID:              uuidp,
-		ObjectStorage:     object,
-		Password:          passwordp,
-		Kmutex:            kmux,
-		TemplatedEmailer:  temailer,
-		DbClient:          client,
-		UserStorer:        usr_storer,
-		SmartFolderStorer: smartfolder_s,
-		ObjectFileStorer:  obj_storer,
+		Config:              appCfg,
+		Logger:              loggerp,
+		UUID:                uuidp,
+		ObjectStorage:       object,
+		Password:            passwordp,
+		Kmutex:              kmux,
+		TemplatedEmailer:    temailer,
+		DbClient:            client,
+		UserStorer:          usr_storer,
+		SmartFolderStorer:   smartfolder_s,
+		ObjectFileStorer:    obj_storer,
+		ShareableLinkStorer: sl_storer,
+		TenantStorer:        tenant_storer,
+		AuditEvent:          ae_controller,
+		ShareableLink:       sl_controller,
 	}
 	s.Logger.Debug("smartfolder controller initialization started...")
 	s.Logger.Debug("smartfolder controller initialized")
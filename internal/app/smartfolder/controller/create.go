@@ -8,8 +8,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
@@ -19,6 +22,9 @@ type SmartFolderCreateRequestIDO struct {
 	Category    uint64 `bson:"category,omitempty" json:"category,omitempty"`
 	SubCategory uint64 `bson:"sub_category,omitempty" json:"sub_category,omitempty"`
 	SortNumber  int8   `bson:"sort_number" json:"sort_number"`
+
+	// AccessControlList restricts the smart folder to the users listed.
+	AccessControlList []*policy.AccessControlEntry `bson:"access_control_list,omitempty" json:"access_control_list,omitempty"`
 }
 
 func (impl *SmartFolderControllerImpl) validateCreateRequest(ctx context.Context, dirtyData *SmartFolderCreateRequestIDO) error {
@@ -74,6 +80,17 @@ func (impl *SmartFolderControllerImpl) Create(ctx context.Context, requestData *
 		impl.Logger.Error("validation error", slog.Any("error", err))
 		return nil, err
 	}
+	if err := policy.Authorize(ctx, policy.PermissionEdit); err != nil {
+		impl.Logger.Warn("authenticated user cannot create smart folders", slog.Any("userID", userID))
+		return nil, err
+	}
+	var acl []*policy.AccessControlEntry
+	if len(requestData.AccessControlList) > 0 {
+		var err error
+		if acl, err = impl.prepareAccessControlList(ctx, requestData.AccessControlList); err != nil {
+			return nil, err
+		}
+	}
 
 	// switch role {
 	// case u_s.UserRoleExecutive, u_s.UserRoleManagement, u_s.UserRoleFrontlineStaff:
@@ -95,6 +112,9 @@ func (impl *SmartFolderControllerImpl) Create(ctx context.Context, requestData *
 	}
 	defer session.EndSession(ctx)
 
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 
@@ -119,12 +139,20 @@ func (impl *SmartFolderControllerImpl) Create(ctx context.Context, requestData *
 		hh.SubCategory = requestData.SubCategory
 		hh.SortNumber = requestData.SortNumber
 		hh.Status = smartfolder_s.StatusActive
+		hh.AccessControlList = acl
 
 		// Save to our database.
 		if err := impl.SmartFolderStorer.Create(sessCtx, hh); err != nil {
 			impl.Logger.Error("database create error", slog.Any("error", err))
 			return nil, err
 		}
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionCreate,
+			TargetType: auditevent_s.TargetTypeSmartFolder,
+			TargetID:   hh.ID,
+			TargetName: hh.Name,
+			After:      hh,
+		}
 
 		////
 		//// Exit our transaction successfully.
@@ -140,6 +168,9 @@ func (impl *SmartFolderControllerImpl) Create(ctx context.Context, requestData *
 			slog.Any("error", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, audit); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return result.(*smartfolder_s.SmartFolder), nil
 }
@@ -2,14 +2,29 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"log/slog"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
+// DeleteByID function moves the smart folder and its object files to the
+// trash of the tenant. Nothing is removed from the object storage until the
+// smart folder is purged.
 func (impl *SmartFolderControllerImpl) DeleteByID(ctx context.Context, sfid primitive.ObjectID) error {
-	// STEP 1: Lookup the record or error.
+	// Extract from our session the following data.
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	userName, _ := ctx.Value(constants.SessionUserName).(string)
+
+	// STEP 1: Lookup the record or error. The lookup enforces tenancy.
 	smartfolder, err := impl.GetByID(ctx, sfid)
 	if err != nil {
 		impl.Logger.Error("database get by id error", slog.Any("error", err))
@@ -17,32 +32,64 @@ func (impl *SmartFolderControllerImpl) DeleteByID(ctx context.Context, sfid prim
 	}
 	if smartfolder == nil {
 		impl.Logger.Error("database returns nothing from get by id")
+		return httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := policy.AuthorizeRecord(ctx, smartfolder.TenantID, smartfolder.AccessControlList, policy.PermissionDelete); err != nil {
+		impl.Logger.Warn("smartfolder access denied", slog.Any("id", sfid))
 		return err
 	}
 
-	// STEP 2: Get all the files that were uploaded to our object store and del.
-	keys, err := impl.ObjectFileStorer.ListObjectKeysBySmartFolderID(ctx, sfid)
+	before := auditevent_c.Snapshot(smartfolder)
+	smartfolder.DeletedAt = time.Now()
+	smartfolder.DeletedByUserID = userID
+	smartfolder.DeletedByUserName = userName
+	smartfolder.PurgeAt = smartfolder.DeletedAt.Add(impl.trashRetention())
+
+	////
+	//// Start the transaction.
+	////
+
+	session, err := impl.DbClient.StartSession()
 	if err != nil {
-		impl.Logger.Error("failed getting object keys by smart folder id", slog.Any("error", err))
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
 		return err
 	}
-	if len(keys) > 0 {
-		if err := impl.ObjectStorage.DeleteByKeys(ctx, keys); err != nil {
-			impl.Logger.Warn("failed deleting object from object store", slog.Any("error", err))
-			// Skip error and continue...
+	defer session.EndSession(ctx)
+
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// STEP 2: Move the smart folder to the trash.
+		if err := impl.SmartFolderStorer.UpdateByID(sessCtx, smartfolder); err != nil {
+			impl.Logger.Error("smartfolder update by id error", slog.Any("error", err))
+			return nil, err
 		}
-	}
 
-	// Step 3: Delete all the object files related.
-	if err := impl.ObjectFileStorer.DeleteBySmartFolderID(ctx, sfid); err != nil {
-		impl.Logger.Error("failed deleting related object files", slog.Any("error", err))
-		return err
+		// STEP 3: Move the related object files to the trash with it.
+		if err := impl.ObjectFileStorer.TrashBySmartFolderID(sessCtx, sfid, smartfolder.DeletedAt, smartfolder.PurgeAt, userID, userName); err != nil {
+			impl.Logger.Error("failed trashing related object files", slog.Any("error", err))
+			return nil, err
+		}
+
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionDelete,
+			TargetType: auditevent_s.TargetTypeSmartFolder,
+			TargetID:   smartfolder.ID,
+			TargetName: smartfolder.Name,
+			Before:     before,
+			After:      smartfolder,
+		}
+		return nil, nil
 	}
 
-	// STEP 4: Delete from database.
-	if err := impl.SmartFolderStorer.DeleteByID(ctx, sfid); err != nil {
-		impl.Logger.Error("database delete by id error", slog.Any("error", err))
+	if _, err := session.WithTransaction(ctx, transactionFunc); err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
 		return err
 	}
+	if err := impl.AuditEvent.Record(ctx, audit); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 	return nil
 }
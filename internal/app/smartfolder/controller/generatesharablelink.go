@@ -2,108 +2,60 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 
-	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
-	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	shareablelink_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/controller"
 )
 
 type GenerateShareableLinkRequestIDO struct {
-	SmartFolderID primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
-	ExpiresIn     uint64             `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
+	SmartFolderID  primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
+	ExpiresIn      uint64             `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
+	Password       string             `bson:"password,omitempty" json:"password,omitempty"`
+	MaxAccessCount uint64             `bson:"max_access_count,omitempty" json:"max_access_count,omitempty"`
 }
 
 type GenerateShareableLinkResponseIDO struct {
-	URL string `bson:"url,omitempty" json:"url,omitempty"`
-}
-
-func (impl *SmartFolderControllerImpl) validatGenerateShareableLinkRequest(ctx context.Context, dirtyData *GenerateShareableLinkRequestIDO) error {
-	e := make(map[string]string)
-
-	if dirtyData.SmartFolderID.IsZero() {
-		e["smart_folder_id"] = "missing value"
-	}
-	if dirtyData.ExpiresIn == 0 {
-		e["expires_in"] = "missing value"
-	}
-
-	if len(e) != 0 {
-		return httperror.NewForBadRequest(&e)
-	}
-	return nil
+	ShareableLinkID primitive.ObjectID `bson:"shareable_link_id" json:"shareable_link_id"`
+	URL             string             `bson:"url,omitempty" json:"url,omitempty"`
+	ExpiryDate      time.Time          `bson:"expiry_date" json:"expiry_date"`
+	ExpiresIn       uint64             `bson:"expires_in,omitempty" json:"expires_in,omitempty"`
 }
 
+// GenerateShareableLink function creates a shareable link for the smart
+// folder through the shareable link controller and returns its public URL.
 func (impl *SmartFolderControllerImpl) GenerateShareableLink(ctx context.Context, requestData *GenerateShareableLinkRequestIDO) (*GenerateShareableLinkResponseIDO, error) {
-	//
-	// Get variables from our user authenticated session.
-	//
-
-	tid, _ := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
-	// // role, _ := ctx.Value(constants.SessionUserRole).(int8)
-	// userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
-	// userName, _ := ctx.Value(constants.SessionUserName).(string)
-	// ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
-
-	// DEVELOPERS NOTE:
-	// Every submission needs to have a unique `public id` (PID)
-	// generated. The following needs to happen to generate the unique PID:
-	// 1. Make the `Create` function be `atomic` and thus lock this function.
-	// 2. Count total records in system (for particular tenant).
-	// 3. Generate PID.
-	// 4. Apply the PID to the record.
-	// 5. Unlock this `Create` function to be usable again by other calls after
-	//    the function successfully submits the record into our system.
-	impl.Kmutex.Lockf("generate-smart-folder-shareable-link-by-tenant-%s", tid.Hex())
-	defer impl.Kmutex.Unlockf("generate-smart-folder-shareable-link-by-tenant-%s", tid.Hex())
-
-	//
-	// Perform our validation and return validation error on any issues detected.
-	//
-
-	if err := impl.validatGenerateShareableLinkRequest(ctx, requestData); err != nil {
-		impl.Logger.Error("validation error", slog.Any("error", err))
-		return nil, err
-	}
-
-	// switch role {
-	// case u_s.UserRoleExecutive, u_s.UserRoleManagement, u_s.UserRoleFrontlineStaff:
-	// 	break
-	// default:
-	// 	impl.Logger.Error("you do not have permission to create a client")
-	// 	return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission to create a client")
-	// }
-
-	////
-	//// Start the transaction.
-	////
-
-	session, err := impl.DbClient.StartSession()
+	sl, err := impl.ShareableLink.Create(ctx, &shareablelink_c.ShareableLinkCreateRequestIDO{
+		SmartFolderID:  requestData.SmartFolderID,
+		ExpiresIn:      requestData.ExpiresIn,
+		Password:       requestData.Password,
+		MaxAccessCount: requestData.MaxAccessCount,
+	})
 	if err != nil {
-		impl.Logger.Error("start session error",
-			slog.Any("error", err))
 		return nil, err
 	}
-	defer session.EndSession(ctx)
-
-	// Define a transaction function with a series of operations
-	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
-
-		return "xxxyyyzzz", nil
-	}
-
-	// Start a transaction
-	result, err := session.WithTransaction(ctx, transactionFunc)
-	if err != nil {
-		impl.Logger.Error("session failed error",
-			slog.Any("error", err))
-		return nil, err
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionGenerateShareableLink,
+		TargetType: auditevent_s.TargetTypeSmartFolder,
+		TargetID:   sl.SmartFolderID,
+		TargetName: sl.SmartFolderName,
+		After:      sl,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
 	}
 
+	// Generate the public URL which the frontend will handle for the
+	// unauthenticated visitor.
 	res := &GenerateShareableLinkResponseIDO{
-		URL: result.(string),
+		ShareableLinkID: sl.ID,
+		URL:             fmt.Sprintf("https://%s/public/shareable-link/%s", impl.Config.AppServer.DomainName, sl.ID.Hex()),
+		ExpiryDate:      sl.ExpiryDate,
+		ExpiresIn:       sl.ExpiresIn,
 	}
 
 	return res, nil
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 )
 
 func (c *SmartFolderControllerImpl) GetByID(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error) {
@@ -16,5 +17,14 @@ func (c *SmartFolderControllerImpl) GetByID(ctx context.Context, id primitive.Ob
 		c.Logger.Error("database get by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if m != nil {
+		if err := policy.AuthorizeRecord(ctx, m.TenantID, m.AccessControlList, policy.PermissionView); err != nil {
+			c.Logger.Warn("smartfolder access denied", slog.Any("id", id))
+			return nil, err
+		}
+		if err := requireNotTrashed(m); err != nil {
+			return nil, err
+		}
+	}
 	return m, err
 }
@@ -10,14 +10,18 @@ import (
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	t_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 )
 
 func (c *SmartFolderControllerImpl) ListByFilter(ctx context.Context, f *t_s.SmartFolderPaginationListFilter) (*t_s.SmartFolderPaginationListResult, error) {
-	// // Extract from our session the following data.
-	tenantID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
-
 	// Apply filtering based on ownership and role.
-	f.TenantID = tenantID // Manditory
+	f.TenantID = policy.SessionTenantID(ctx) // Manditory
+	if err := policy.Authorize(ctx, policy.PermissionView); err != nil {
+		return nil, err
+	}
+	if policy.IsRestrictedFromListing(ctx) {
+		f.VisibleToUserID, _ = ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	}
 
 	c.Logger.Debug("listing using filter options:",
 		slog.Any("Cursor", f.Cursor),
@@ -68,11 +72,14 @@ func (c *SmartFolderControllerImpl) ListByFilter(ctx context.Context, f *t_s.Sma
 // }
 
 func (c *SmartFolderControllerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *smartfolder_s.SmartFolderPaginationListFilter) ([]*smartfolder_s.SmartFolderAsSelectOption, error) {
-	// // Extract from our session the following data.
-	tenantID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
-
 	// Apply filtering based on ownership and role.
-	f.TenantID = tenantID // Manditory
+	f.TenantID = policy.SessionTenantID(ctx) // Manditory
+	if err := policy.Authorize(ctx, policy.PermissionView); err != nil {
+		return nil, err
+	}
+	if policy.IsRestrictedFromListing(ctx) {
+		f.VisibleToUserID, _ = ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	}
 
 	c.Logger.Debug("listing using filter options:",
 		slog.Any("Cursor", f.Cursor),
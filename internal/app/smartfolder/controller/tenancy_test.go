@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// fakeSmartFolderStorer returns a single smart folder and records which
+// modifying calls were made. Unimplemented methods panic.
+type fakeSmartFolderStorer struct {
+	smartfolder_s.SmartFolderStorer
+	smartFolder *smartfolder_s.SmartFolder
+	lastFilter  *smartfolder_s.SmartFolderPaginationListFilter
+	modified    bool
+}
+
+func (s *fakeSmartFolderStorer) GetByID(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error) {
+	return s.smartFolder, nil
+}
+
+func (s *fakeSmartFolderStorer) UpdateByID(ctx context.Context, m *smartfolder_s.SmartFolder) error {
+	s.modified = true
+	return nil
+}
+
+func (s *fakeSmartFolderStorer) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	s.modified = true
+	return nil
+}
+
+func (s *fakeSmartFolderStorer) ListByFilter(ctx context.Context, f *smartfolder_s.SmartFolderPaginationListFilter) (*smartfolder_s.SmartFolderPaginationListResult, error) {
+	s.lastFilter = f
+	return &smartfolder_s.SmartFolderPaginationListResult{}, nil
+}
+
+// fakeObjectFileStorer fails the test if the object files are reached.
+type fakeObjectFileStorer struct {
+	objectfile_s.ObjectFileStorer
+	t *testing.T
+}
+
+func (s *fakeObjectFileStorer) ListObjectKeysBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) ([]string, error) {
+	s.t.Error("object files must not be reached for another tenant's smart folder")
+	return nil, errors.New("unexpected call")
+}
+
+func (s *fakeObjectFileStorer) ListBySmartFolderID(ctx context.Context, sfid primitive.ObjectID) ([]*objectfile_s.ObjectFile, error) {
+	s.t.Error("object files must not be reached for another tenant's smart folder")
+	return nil, errors.New("unexpected call")
+}
+
+func assertForbidden(t *testing.T, err error) {
+	t.Helper()
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+		t.Errorf("expected forbidden error but received %v", err)
+	}
+}
+
+func TestCrossTenantSmartFolderAccessIsForbidden(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	sf := &smartfolder_s.SmartFolder{ID: primitive.NewObjectID(), TenantID: owner}
+
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, other)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, int8(user_s.UserRoleExecutive))
+
+	storer := &fakeSmartFolderStorer{smartFolder: sf}
+	c := &SmartFolderControllerImpl{
+		Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		SmartFolderStorer: storer,
+		ObjectFileStorer:  &fakeObjectFileStorer{t: t},
+	}
+
+	_, err := c.GetByID(ctx, sf.ID)
+	assertForbidden(t, err)
+
+	_, err = c.GetArchiveByID(ctx, sf.ID)
+	assertForbidden(t, err)
+
+	_, err = c.ArchiveByID(ctx, sf.ID)
+	assertForbidden(t, err)
+
+	assertForbidden(t, c.DeleteByID(ctx, sf.ID))
+
+	if storer.modified {
+		t.Error("smart folder of another tenant was modified")
+	}
+}
+
+func TestSmartFolderListIsScopedToSessionTenant(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, owner)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, int8(user_s.UserRoleExecutive))
+
+	storer := &fakeSmartFolderStorer{}
+	c := &SmartFolderControllerImpl{
+		Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		SmartFolderStorer: storer,
+	}
+
+	if _, err := c.ListByFilter(ctx, &smartfolder_s.SmartFolderPaginationListFilter{TenantID: other}); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if storer.lastFilter.TenantID != owner {
+		t.Errorf("expected list to be scoped to %v but was %v", owner, storer.lastFilter.TenantID)
+	}
+}
+
+func TestSmartFolderAccessControlList(t *testing.T) {
+	owner := primitive.NewObjectID()
+	boardMember := primitive.NewObjectID()
+	sf := &smartfolder_s.SmartFolder{
+		ID:       primitive.NewObjectID(),
+		TenantID: owner,
+		AccessControlList: []*policy.AccessControlEntry{
+			{UserID: boardMember, Permissions: []int8{policy.PermissionView}},
+		},
+	}
+	storer := &fakeSmartFolderStorer{smartFolder: sf}
+	c := &SmartFolderControllerImpl{
+		Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		SmartFolderStorer: storer,
+		ObjectFileStorer:  &fakeObjectFileStorer{t: t},
+	}
+
+	// Frontline staff who are not listed can neither see nor list the folder.
+	staffID := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, owner)
+	ctx = context.WithValue(ctx, constants.SessionUserID, staffID)
+	ctx = context.WithValue(ctx, constants.SessionUserRole, int8(user_s.UserRoleFrontlineStaff))
+
+	_, err := c.GetByID(ctx, sf.ID)
+	assertForbidden(t, err)
+
+	_, err = c.GetArchiveByID(ctx, sf.ID)
+	assertForbidden(t, err)
+
+	if _, err := c.ListByFilter(ctx, &smartfolder_s.SmartFolderPaginationListFilter{}); err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if storer.lastFilter.VisibleToUserID != staffID {
+		t.Errorf("expected list to be restricted to %v but was %v", staffID, storer.lastFilter.VisibleToUserID)
+	}
+
+	// Listed users are limited to the permissions they were granted.
+	ctx = context.WithValue(ctx, constants.SessionUserID, boardMember)
+	if _, err := c.GetByID(ctx, sf.ID); err != nil {
+		t.Errorf("expected access for listed user but received %v", err)
+	}
+	_, err = c.ArchiveByID(ctx, sf.ID)
+	assertForbidden(t, err)
+
+	if storer.modified {
+		t.Error("restricted smart folder was modified")
+	}
+}
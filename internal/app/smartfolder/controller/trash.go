@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// requireNotTrashed function returns an error if the smart folder was moved
+// to the trash, in which case it must be restored before being used again.
+func requireNotTrashed(sf *smartfolder_s.SmartFolder) error {
+	if sf.IsTrashed() {
+		return httperror.NewForSingleField(http.StatusNotFound, "id", "smart folder is in the trash")
+	}
+	return nil
+}
+
+// trashRetention returns how long trashed records are kept before purged.
+func (impl *SmartFolderControllerImpl) trashRetention() time.Duration {
+	return time.Duration(impl.Config.AppServer.TrashRetentionInDays) * 24 * time.Hour
+}
+
+// getTrashedSmartFolder function returns the trashed smart folder if the
+// authenticated user is granted the delete permission on it.
+func (impl *SmartFolderControllerImpl) getTrashedSmartFolder(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error) {
+	sf, err := impl.SmartFolderStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if sf == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := policy.AuthorizeRecord(ctx, sf.TenantID, sf.AccessControlList, policy.PermissionDelete); err != nil {
+		impl.Logger.Warn("smartfolder access denied", slog.Any("id", id))
+		return nil, err
+	}
+	if !sf.IsTrashed() {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "smart folder is not in the trash")
+	}
+	return sf, nil
+}
+
+// ListTrashByFilter function returns the smart folders in the trash of the
+// tenant of the authenticated user.
+func (impl *SmartFolderControllerImpl) ListTrashByFilter(ctx context.Context, f *smartfolder_s.SmartFolderPaginationListFilter) (*smartfolder_s.SmartFolderPaginationListResult, error) {
+	// Apply filtering based on ownership and role.
+	f.TenantID = policy.SessionTenantID(ctx) // Manditory
+	f.Trashed = true
+	if err := policy.Authorize(ctx, policy.PermissionDelete); err != nil {
+		return nil, err
+	}
+	if policy.IsRestrictedFromListing(ctx) {
+		f.VisibleToUserID, _ = ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	}
+
+	m, err := impl.SmartFolderStorer.ListByFilter(ctx, f)
+	if err != nil {
+		impl.Logger.Error("database list by filter error", slog.Any("error", err))
+		return nil, err
+	}
+	return m, nil
+}
+
+// RestoreByID function takes the smart folder out of the trash with the object
+// files which were trashed with it.
+func (impl *SmartFolderControllerImpl) RestoreByID(ctx context.Context, id primitive.ObjectID) (*smartfolder_s.SmartFolder, error) {
+	sf, err := impl.getTrashedSmartFolder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := auditevent_c.Snapshot(sf)
+	sf.DeletedAt = time.Time{}
+	sf.DeletedByUserID = primitive.NilObjectID
+	sf.DeletedByUserName = ""
+	sf.PurgeAt = time.Time{}
+
+	session, err := impl.DbClient.StartSession()
+	if err != nil {
+		impl.Logger.Error("start session error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if err := impl.SmartFolderStorer.UpdateByID(sessCtx, sf); err != nil {
+			impl.Logger.Error("smartfolder update by id error", slog.Any("error", err))
+			return nil, err
+		}
+		if err := impl.ObjectFileStorer.RestoreBySmartFolderID(sessCtx, sf.ID); err != nil {
+			impl.Logger.Error("failed restoring related object files", slog.Any("error", err))
+			return nil, err
+		}
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionRestore,
+			TargetType: auditevent_s.TargetTypeSmartFolder,
+			TargetID:   sf.ID,
+			TargetName: sf.Name,
+			Before:     before,
+			After:      sf,
+		}
+		return sf, nil
+	}
+
+	if _, err := session.WithTransaction(ctx, transactionFunc); err != nil {
+		impl.Logger.Error("session failed error",
+			slog.Any("error", err))
+		return nil, err
+	}
+	if err := impl.AuditEvent.Record(ctx, audit); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return sf, nil
+}
+
+// PurgeByID function permanently deletes the trashed smart folder, its object
+// files and their content from the object storage.
+func (impl *SmartFolderControllerImpl) PurgeByID(ctx context.Context, id primitive.ObjectID) error {
+	sf, err := impl.getTrashedSmartFolder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := impl.purge(ctx, sf.ID); err != nil {
+		return err
+	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionPurge,
+		TargetType: auditevent_s.TargetTypeSmartFolder,
+		TargetID:   sf.ID,
+		TargetName: sf.Name,
+		Before:     sf,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	return nil
+}
+
+// PurgeExpiredTrash function permanently deletes the smart folders of every
+// tenant which were kept in the trash for the entire retention window and
+// returns how many were purged.
+func (impl *SmartFolderControllerImpl) PurgeExpiredTrash(ctx context.Context) (int, error) {
+	ids, err := impl.SmartFolderStorer.ListIDsToPurge(ctx, time.Now())
+	if err != nil {
+		impl.Logger.Error("failed listing smart folders to purge", slog.Any("error", err))
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := impl.purge(ctx, id); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge function deletes every object of the smart folder from the object
+// storage, then the object files and finally the smart folder.
+func (impl *SmartFolderControllerImpl) purge(ctx context.Context, sfid primitive.ObjectID) error {
+	// STEP 1: Get all the files that were uploaded to our object store and del.
+	keys, err := impl.ObjectFileStorer.ListObjectKeysBySmartFolderID(ctx, sfid)
+	if err != nil {
+		impl.Logger.Error("failed getting object keys by smart folder id", slog.Any("error", err))
+		return err
+	}
+	if len(keys) > 0 {
+		if err := impl.ObjectStorage.DeleteByKeys(ctx, keys); err != nil {
+			impl.Logger.Warn("failed deleting object from object store", slog.Any("error", err))
+			// Skip error and continue...
+		}
+	}
+
+	// Step 2: Delete all the object files related and remove them from the
+	// usage of the tenant.
+	usage, err := impl.ObjectFileStorer.GetUsageBySmartFolderID(ctx, sfid)
+	if err != nil {
+		impl.Logger.Error("failed getting usage by smart folder id", slog.Any("error", err))
+		return err
+	}
+	if err := impl.ObjectFileStorer.DeleteBySmartFolderID(ctx, sfid); err != nil {
+		impl.Logger.Error("failed deleting related object files", slog.Any("error", err))
+		return err
+	}
+	if usage.FileCount > 0 {
+		if err := impl.TenantStorer.AdjustUsage(ctx, usage.TenantID, -usage.UsedInBytes, -usage.FileCount); err != nil {
+			impl.Logger.Warn("failed releasing tenant usage",
+				slog.Any("tenant_id", usage.TenantID),
+				slog.Any("error", err))
+		}
+	}
+
+	// STEP 3: Delete from database.
+	if err := impl.SmartFolderStorer.DeleteByID(ctx, sfid); err != nil {
+		impl.Logger.Error("database delete by id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -9,8 +9,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
 
@@ -21,6 +24,10 @@ type SmartFolderUpdateRequestIDO struct {
 	Category    uint64             `bson:"category,omitempty" json:"category,omitempty"`
 	SubCategory uint64             `bson:"sub_category,omitempty" json:"sub_category,omitempty"`
 	SortNumber  int8               `bson:"sort_number" json:"sort_number"`
+
+	// AccessControlList replaces the access control list of the smart folder
+	// when provided; an empty list removes the restriction.
+	AccessControlList []*policy.AccessControlEntry `bson:"access_control_list,omitempty" json:"access_control_list,omitempty"`
 }
 
 func (impl *SmartFolderControllerImpl) validateUpdateRequest(ctx context.Context, dirtyData *SmartFolderUpdateRequestIDO) error {
@@ -64,6 +71,14 @@ func (impl *SmartFolderControllerImpl) UpdateByID(ctx context.Context, requestDa
 	userName, _ := ctx.Value(constants.SessionUserName).(string)
 	ipAddress, _ := ctx.Value(constants.SessionIPAddress).(string)
 
+	var acl []*policy.AccessControlEntry
+	if requestData.AccessControlList != nil {
+		var err error
+		if acl, err = impl.prepareAccessControlList(ctx, requestData.AccessControlList); err != nil {
+			return nil, err
+		}
+	}
+
 	// switch role {
 	// case u_s.UserRoleExecutive, u_s.UserRoleManagement, u_s.UserRoleFrontlineStaff:
 	// 	break
@@ -84,6 +99,9 @@ func (impl *SmartFolderControllerImpl) UpdateByID(ctx context.Context, requestDa
 	}
 	defer session.EndSession(ctx)
 
+	// The audit event is recorded once the transaction is committed.
+	var audit *auditevent_c.AuditEventRecordRequestIDO
+
 	// Define a transaction function with a series of operations
 	transactionFunc := func(sessCtx mongo.SessionContext) (interface{}, error) {
 
@@ -101,6 +119,15 @@ func (impl *SmartFolderControllerImpl) UpdateByID(ctx context.Context, requestDa
 			impl.Logger.Warn("smartfolder does not exist validation error")
 			return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
 		}
+		if err := policy.AuthorizeRecord(ctx, hh.TenantID, hh.AccessControlList, policy.PermissionEdit); err != nil {
+			impl.Logger.Warn("smartfolder access denied", slog.Any("id", requestData.ID))
+			return nil, err
+		}
+		if err := requireNotTrashed(hh); err != nil {
+			return nil, err
+		}
+
+		before := auditevent_c.Snapshot(hh)
 
 		////
 		//// Update primary record.
@@ -119,11 +146,22 @@ func (impl *SmartFolderControllerImpl) UpdateByID(ctx context.Context, requestDa
 		hh.Category = requestData.Category
 		hh.SubCategory = requestData.SubCategory
 		hh.SortNumber = requestData.SortNumber
+		if requestData.AccessControlList != nil {
+			hh.AccessControlList = acl
+		}
 
 		if err := impl.SmartFolderStorer.UpdateByID(sessCtx, hh); err != nil {
 			impl.Logger.Error("smartfolder update by id error", slog.Any("error", err))
 			return nil, err
 		}
+		audit = &auditevent_c.AuditEventRecordRequestIDO{
+			Action:     auditevent_s.ActionUpdate,
+			TargetType: auditevent_s.TargetTypeSmartFolder,
+			TargetID:   hh.ID,
+			TargetName: hh.Name,
+			Before:     before,
+			After:      hh,
+		}
 
 		////
 		//// Update related records.
@@ -145,6 +183,9 @@ func (impl *SmartFolderControllerImpl) UpdateByID(ctx context.Context, requestDa
 			slog.Any("error", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, audit); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return result.(*smartfolder_s.SmartFolder), nil
 }
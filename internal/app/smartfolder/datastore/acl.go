@@ -0,0 +1,71 @@
+package datastore
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+)
+
+// visibleToUserConditions returns the `$or` conditions matching the smart
+// folders without an access control list or with one granting the user the
+// view permission.
+func visibleToUserConditions(uid primitive.ObjectID) bson.A {
+	return bson.A{
+		bson.M{"access_control_list.0": bson.M{"$exists": false}},
+		bson.M{"access_control_list": bson.M{"$elemMatch": bson.M{
+			"user_id":     uid,
+			"permissions": policy.PermissionView,
+		}}},
+	}
+}
+
+// addVisibleToUserConditions adds the `visibleToUserConditions` to the filter
+// without replacing the `$or` conditions of the pagination cursor, if any.
+func addVisibleToUserConditions(filter bson.M, uid primitive.ObjectID) {
+	visible := visibleToUserConditions(uid)
+	if cursor, ok := filter["$or"]; ok {
+		delete(filter, "$or")
+		filter["$and"] = bson.A{
+			bson.M{"$or": cursor},
+			bson.M{"$or": visible},
+		}
+		return
+	}
+	filter["$or"] = visible
+}
+
+// ListIDsHiddenFromUser returns the ID of every smart folder of the tenant
+// whose access control list does not grant the user the view permission.
+func (impl SmartFolderStorerImpl) ListIDsHiddenFromUser(ctx context.Context, tid primitive.ObjectID, uid primitive.ObjectID) ([]primitive.ObjectID, error) {
+	filter := bson.M{
+		"tenant_id":             tid,
+		"access_control_list.0": bson.M{"$exists": true},
+		"access_control_list": bson.M{"$not": bson.M{"$elemMatch": bson.M{
+			"user_id":     uid,
+			"permissions": policy.PermissionView,
+		}}},
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
@@ -11,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
 )
 
 const (
@@ -40,6 +41,22 @@ type SmartFolder struct {
 	ModifiedFromIPAddress string             `bson:"modified_from_ip_address" json:"modified_from_ip_address"`
 	TenantID              primitive.ObjectID `bson:"tenant_id" json:"tenant_id"`
 	TenantName            string             `bson:"tenant_name" json:"tenant_name"`
+
+	// AccessControlList restricts the smart folder to the users listed. An
+	// empty list means every user of the tenant has access based on their role.
+	AccessControlList []*policy.AccessControlEntry `bson:"access_control_list,omitempty" json:"access_control_list,omitempty"`
+
+	// DeletedAt is set when the smart folder was moved to the trash. The
+	// smart folder and its object files are purged once `PurgeAt` passed.
+	DeletedAt         time.Time          `bson:"deleted_at" json:"deleted_at"`
+	DeletedByUserID   primitive.ObjectID `bson:"deleted_by_user_id" json:"deleted_by_user_id,omitempty"`
+	DeletedByUserName string             `bson:"deleted_by_user_name" json:"deleted_by_user_name,omitempty"`
+	PurgeAt           time.Time          `bson:"purge_at" json:"purge_at"`
+}
+
+// IsTrashed returns true if the smart folder was moved to the trash.
+func (sf *SmartFolder) IsTrashed() bool {
+	return !sf.DeletedAt.IsZero()
 }
 
 type SmartFolderListResult struct {
@@ -66,6 +83,8 @@ type SmartFolderStorer interface {
 	ListByFilter(ctx context.Context, f *SmartFolderPaginationListFilter) (*SmartFolderPaginationListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *SmartFolderPaginationListFilter) ([]*SmartFolderAsSelectOption, error)
 	ListByTenantID(ctx context.Context, tid primitive.ObjectID) (*SmartFolderPaginationListResult, error)
+	ListIDsHiddenFromUser(ctx context.Context, tid primitive.ObjectID, uid primitive.ObjectID) ([]primitive.ObjectID, error)
+	ListIDsToPurge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 }
 
@@ -83,6 +102,8 @@ func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) Sm
 		{Keys: bson.D{{Key: "tenant_id", Value: 1}}},
 		{Keys: bson.D{{Key: "public_id", Value: -1}}},
 		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "access_control_list.user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "purge_at", Value: 1}}},
 		{Keys: bson.D{
 			{"name", "text"},
 		}},
@@ -63,13 +63,13 @@ func (impl SmartFolderStorerImpl) GetLatestByTenantID(ctx context.Context, tenan
 	opts := options.Find().SetSort(bson.D{{"public_id", -1}}).SetLimit(1)
 
 	var order SmartFolder
-	cursor, err := impl.Collection.Find(context.Background(), filter, opts)
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(ctx)
 
-	if cursor.Next(context.Background()) {
+	if cursor.Next(ctx) {
 		err := cursor.Decode(&order)
 		if err != nil {
 			return nil, err
@@ -9,7 +9,7 @@ import (
 )
 
 func (impl SmartFolderStorerImpl) ListByFilter(ctx context.Context, f *SmartFolderPaginationListFilter) (*SmartFolderPaginationListResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Create the paginated filter based on the cursor
@@ -29,6 +29,10 @@ func (impl SmartFolderStorerImpl) ListByFilter(ctx context.Context, f *SmartFold
 	if f.Status != 0 {
 		filter["status"] = f.Status
 	}
+	filter["deleted_at"] = trashCondition(f.Trashed)
+	if !f.VisibleToUserID.IsZero() {
+		addVisibleToUserConditions(filter, f.VisibleToUserID)
+	}
 
 	impl.Logger.Debug("listing filter:",
 		slog.Any("filter", filter))
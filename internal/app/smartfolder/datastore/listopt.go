@@ -10,7 +10,7 @@ import (
 )
 
 func (impl SmartFolderStorerImpl) ListAsSelectOptionByFilter(ctx context.Context, f *SmartFolderPaginationListFilter) ([]*SmartFolderAsSelectOption, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Get a reference to the collection
@@ -42,6 +42,10 @@ func (impl SmartFolderStorerImpl) ListAsSelectOptionByFilter(ctx context.Context
 	if f.Status != 0 {
 		query["status"] = f.Status
 	}
+	query["deleted_at"] = trashCondition(f.Trashed)
+	if !f.VisibleToUserID.IsZero() {
+		addVisibleToUserConditions(query, f.VisibleToUserID)
+	}
 
 	// Full-text search
 	if f.SearchText != "" {
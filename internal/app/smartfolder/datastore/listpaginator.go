@@ -27,6 +27,13 @@ type SmartFolderPaginationListFilter struct {
 	TenantID   primitive.ObjectID
 	Status     int8
 	SearchText string
+
+	// VisibleToUserID hides the smart folders with an access control list
+	// which does not grant this user the view permission.
+	VisibleToUserID primitive.ObjectID
+
+	// Trashed lists the smart folders in the trash instead of the others.
+	Trashed bool
 }
 
 // SmartFolderPaginationListResult represents the paginated list results for
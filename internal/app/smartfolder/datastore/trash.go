@@ -0,0 +1,51 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// trashCondition returns the condition to apply on the `deleted_at` field to
+// list either the trashed smart folders or the others. Records created before
+// the trash existed do not have the field.
+func trashCondition(trashed bool) bson.M {
+	if trashed {
+		return bson.M{"$gt": time.Time{}}
+	}
+	return bson.M{"$in": bson.A{nil, time.Time{}}}
+}
+
+// ListIDsToPurge returns the ID of every trashed smart folder, of every
+// tenant, whose retention window ended before the time.
+func (impl SmartFolderStorerImpl) ListIDsToPurge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"deleted_at": trashCondition(true),
+		"purge_at":   bson.M{"$lte": before},
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
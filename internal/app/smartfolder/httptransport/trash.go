@@ -0,0 +1,86 @@
+package httptransport
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	smartfolder_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f := &smartfolder_s.SmartFolderPaginationListFilter{
+		Cursor:    "",
+		PageSize:  25,
+		SortField: "created_at",
+		SortOrder: -1, // 1=ascending | -1=descending
+	}
+
+	// Here is where you extract url parameters.
+	query := r.URL.Query()
+
+	cursor := query.Get("cursor")
+	if cursor != "" {
+		f.Cursor = cursor
+	}
+
+	pageSize := query.Get("page_size")
+	if pageSize != "" {
+		pageSize, _ := strconv.ParseInt(pageSize, 10, 64)
+		if pageSize == 0 || pageSize > 250 {
+			pageSize = 250
+		}
+		f.PageSize = pageSize
+	}
+
+	searchKeyword := query.Get("search")
+	if searchKeyword != "" {
+		f.SearchText = searchKeyword
+	}
+
+	m, err := h.Controller.ListTrashByFilter(ctx, f)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalListResponse(m, w)
+}
+
+func (h *Handler) RestoreByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	res, err := h.Controller.RestoreByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDetailResponse(res, w)
+}
+
+func (h *Handler) PurgeByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	if err := h.Controller.PurgeByID(ctx, objectID); err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
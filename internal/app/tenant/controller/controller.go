@@ -9,9 +9,12 @@ import (
 
 	mg "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/emailer/mailgun"
 	object_storage "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
 	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	org_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	uploadsession_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/kmutex"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
@@ -26,17 +29,22 @@ type TenantController interface {
 	ListAsSelectOptionByFilter(ctx context.Context, f *domain.TenantListFilter) ([]*domain.TenantAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 	CreateComment(ctx context.Context, customerID primitive.ObjectID, content string) (*org_d.Tenant, error)
+	GetUsageByID(ctx context.Context, id primitive.ObjectID) (*TenantUsageResponseIDO, error)
+	RecalculateUsage(ctx context.Context) (int, error)
 }
 
 type TenantControllerImpl struct {
-	Config        *config.Conf
-	Logger        *slog.Logger
-	UUID          uuid.Provider
-	Kmutex        kmutex.Provider
-	ObjectStorage object_storage.ObjectStorager
-	Emailer       mg.Emailer
-	DbClient      *mongo.Client
-	TenantStorer  tenant_s.TenantStorer
+	Config              *config.Conf
+	Logger              *slog.Logger
+	UUID                uuid.Provider
+	Kmutex              kmutex.Provider
+	ObjectStorage       object_storage.ObjectStorager
+	Emailer             mg.Emailer
+	DbClient            *mongo.Client
+	TenantStorer        tenant_s.TenantStorer
+	ObjectFileStorer    objectfile_s.ObjectFileStorer
+	UploadSessionStorer uploadsession_s.UploadSessionStorer
+	AuditEvent          auditevent_c.AuditEventController
 }
 
 func NewController(
@@ -48,16 +56,22 @@ func NewController(
 	emailer mg.Emailer,
 	client *mongo.Client,
 	org_storer tenant_s.TenantStorer,
+	of_storer objectfile_s.ObjectFileStorer,
+	us_storer uploadsession_s.UploadSessionStorer,
+	ae_controller auditevent_c.AuditEventController,
 ) TenantController {
 	s := &TenantControllerImpl{
-		Config:        appCfg,
-		Logger:        loggerp,
-		UUID:          uuidp,
-		Kmutex:        kmux,
-		ObjectStorage: object,
-		Emailer:       emailer,
-		DbClient:      client,
-		TenantStorer:  org_storer,
+		Config:              appCfg,
+		Logger:              loggerp,
+		UUID:                uuidp,
+		Kmutex:              kmux,
+		ObjectStorage:       object,
+		Emailer:             emailer,
+		DbClient:            client,
+		TenantStorer:        org_storer,
+		ObjectFileStorer:    of_storer,
+		UploadSessionStorer: us_storer,
+		AuditEvent:          ae_controller,
 	}
 	s.Logger.Debug("Tenant controller initialization started...")
 	s.Logger.Debug("Tenant controller initialized")
@@ -8,6 +8,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	s_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
@@ -31,6 +33,10 @@ func (c *TenantControllerImpl) Create(ctx context.Context, m *s_d.Tenant) (*s_d.
 	c.Kmutex.Lock("create-tenant")
 	defer c.Kmutex.Unlock("create-tenant")
 
+	if m.StorageQuotaInBytes < 0 || m.FileCountQuota < 0 {
+		return nil, httperror.NewForBadRequestWithSingleField("message", "storage quota must not be negative")
+	}
+
 	// Add defaults.
 	m.ID = primitive.NewObjectID()
 	m.CreatedByUserID = userID
@@ -46,6 +52,15 @@ func (c *TenantControllerImpl) Create(ctx context.Context, m *s_d.Tenant) (*s_d.
 		c.Logger.Error("database create error", slog.Any("error", err))
 		return nil, err
 	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionCreate,
+		TargetType: auditevent_s.TargetTypeTenant,
+		TargetID:   m.ID,
+		TargetName: m.Name,
+		After:      m,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return m, nil
 }
@@ -6,6 +6,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log/slog"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	org_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
@@ -27,7 +29,6 @@ func (impl *TenantControllerImpl) DeleteByID(ctx context.Context, id primitive.O
 
 	// Update the database.
 	tenant, err := impl.GetByID(ctx, id)
-	tenant.Status = org_d.TenantArchivedStatus
 	if err != nil {
 		impl.Logger.Error("database get by id error", slog.Any("error", err))
 		return err
@@ -41,11 +42,23 @@ func (impl *TenantControllerImpl) DeleteByID(ctx context.Context, id primitive.O
 		impl.Logger.Warn("root tenant cannot be deleted error")
 		return httperror.NewForForbiddenWithSingleField("role", "root tenant cannot be deleted")
 	}
+	before := auditevent_c.Snapshot(tenant)
+	tenant.Status = org_d.TenantArchivedStatus
 
 	// Save to the database the modified tenant.
 	if err := impl.TenantStorer.UpdateByID(ctx, tenant); err != nil {
 		impl.Logger.Error("database update by id error", slog.Any("error", err))
 		return err
 	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionArchive,
+		TargetType: auditevent_s.TargetTypeTenant,
+		TargetID:   tenant.ID,
+		TargetName: tenant.Name,
+		Before:     before,
+		After:      tenant,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 	return nil
 }
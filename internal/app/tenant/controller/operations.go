@@ -7,6 +7,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log/slog"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	org_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
@@ -48,6 +50,15 @@ func (c *TenantControllerImpl) CreateComment(ctx context.Context, TenantID primi
 		c.Logger.Error("database update by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionCreateComment,
+		TargetType: auditevent_s.TargetTypeTenant,
+		TargetID:   s.ID,
+		TargetName: s.Name,
+		After:      comment,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return s, nil
 }
@@ -8,6 +8,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	domain "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
@@ -26,6 +28,12 @@ func validateUpdateRequest(dirtyData *domain.Tenant) error {
 	if dirtyData.Description == "" {
 		e["description"] = "missing value"
 	}
+	if dirtyData.StorageQuotaInBytes < 0 {
+		e["storage_quota_in_bytes"] = "must not be negative"
+	}
+	if dirtyData.FileCountQuota < 0 {
+		e["file_count_quota"] = "must not be negative"
+	}
 	if len(e) != 0 {
 		return httperror.NewForBadRequest(&e)
 	}
@@ -64,6 +72,8 @@ func (c *TenantControllerImpl) UpdateByID(ctx context.Context, ns *domain.Tenant
 		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not belong to this Tenant")
 	}
 
+	before := auditevent_c.Snapshot(os)
+
 	// Modify our original Tenant.
 	os.ModifiedAt = time.Now()
 	os.ModifiedByUserID = userID
@@ -71,12 +81,30 @@ func (c *TenantControllerImpl) UpdateByID(ctx context.Context, ns *domain.Tenant
 	os.Status = ns.Status
 	os.Name = ns.Name
 	os.Description = ns.Description
+	os.RequireVerifiedEmail = ns.RequireVerifiedEmail
+
+	// Only executives may change the storage quota, otherwise the staff of a
+	// tenant could lift the quota of their own tenant.
+	if userRole == user_d.UserRoleExecutive {
+		os.StorageQuotaInBytes = ns.StorageQuotaInBytes
+		os.FileCountQuota = ns.FileCountQuota
+	}
 
 	// Save to the database the modified Tenant.
 	if err := c.TenantStorer.UpdateByID(ctx, os); err != nil {
 		c.Logger.Error("database update by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if err := c.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionUpdate,
+		TargetType: auditevent_s.TargetTypeTenant,
+		TargetID:   os.ID,
+		TargetName: os.Name,
+		Before:     before,
+		After:      os,
+	}); err != nil {
+		c.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return os, nil
 }
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	user_d "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+type SmartFolderUsageIDO struct {
+	SmartFolderID   primitive.ObjectID `json:"smart_folder_id"`
+	SmartFolderName string             `json:"smart_folder_name"`
+	UsedInBytes     int64              `json:"used_in_bytes"`
+	FileCount       int64              `json:"file_count"`
+}
+
+type ClassificationUsageIDO struct {
+	Classification uint64 `json:"classification"`
+	UsedInBytes    int64  `json:"used_in_bytes"`
+	FileCount      int64  `json:"file_count"`
+}
+
+type TenantUsageResponseIDO struct {
+	TenantID            primitive.ObjectID        `json:"tenant_id"`
+	StorageQuotaInBytes int64                     `json:"storage_quota_in_bytes"`
+	FileCountQuota      int64                     `json:"file_count_quota"`
+	UsedInBytes         int64                     `json:"used_in_bytes"`
+	FileCount           int64                     `json:"file_count"`
+	BySmartFolder       []*SmartFolderUsageIDO    `json:"by_smart_folder"`
+	ByClassification    []*ClassificationUsageIDO `json:"by_classification"`
+}
+
+// GetUsageByID function returns the storage quota and usage of the tenant
+// broken down by smart folder and classification. Trashed object files are
+// included as their content is kept until they are purged.
+func (c *TenantControllerImpl) GetUsageByID(ctx context.Context, id primitive.ObjectID) (*TenantUsageResponseIDO, error) {
+	// Extract from our session the following data.
+	userTenantID := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	userRole := ctx.Value(constants.SessionUserRole).(int8)
+
+	// If user is not administrator nor belongs to the Tenant then error.
+	if userRole != user_d.UserRoleExecutive && id != userTenantID {
+		c.Logger.Error("authenticated user is not staff role nor belongs to the Tenant error",
+			slog.Any("userRole", userRole),
+			slog.Any("userTenantID", userTenantID))
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not belong to this Tenant")
+	}
+
+	t, err := c.TenantStorer.GetByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if t == nil {
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	usage, err := c.TenantStorer.GetUsageByID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database get usage by id error", slog.Any("error", err))
+		return nil, err
+	}
+	groups, err := c.ObjectFileStorer.ListUsageByTenantID(ctx, id)
+	if err != nil {
+		c.Logger.Error("database list usage by tenant id error", slog.Any("error", err))
+		return nil, err
+	}
+
+	res := &TenantUsageResponseIDO{
+		TenantID:            t.ID,
+		StorageQuotaInBytes: t.StorageQuotaInBytes,
+		FileCountQuota:      t.FileCountQuota,
+		UsedInBytes:         usage.UsedInBytes,
+		FileCount:           usage.FileCount,
+		BySmartFolder:       []*SmartFolderUsageIDO{},
+		ByClassification:    []*ClassificationUsageIDO{},
+	}
+	bySmartFolder := map[primitive.ObjectID]*SmartFolderUsageIDO{}
+	byClassification := map[uint64]*ClassificationUsageIDO{}
+	for _, g := range groups {
+		sf, ok := bySmartFolder[g.SmartFolderID]
+		if !ok {
+			sf = &SmartFolderUsageIDO{SmartFolderID: g.SmartFolderID, SmartFolderName: g.SmartFolderName}
+			bySmartFolder[g.SmartFolderID] = sf
+			res.BySmartFolder = append(res.BySmartFolder, sf)
+		}
+		sf.UsedInBytes += g.UsedInBytes
+		sf.FileCount += g.FileCount
+
+		cl, ok := byClassification[g.Classification]
+		if !ok {
+			cl = &ClassificationUsageIDO{Classification: g.Classification}
+			byClassification[g.Classification] = cl
+			res.ByClassification = append(res.ByClassification, cl)
+		}
+		cl.UsedInBytes += g.UsedInBytes
+		cl.FileCount += g.FileCount
+	}
+	return res, nil
+}
+
+// RecalculateUsage function overwrites the usage of every tenant with the
+// usage computed from its object files and the reservations of its active
+// upload sessions, correcting any drift caused by failures between an upload
+// and its accounting. Tenants without object files nor upload sessions are
+// reset to zero. Returns the number of corrected tenants.
+//
+// DEVELOPERS NOTE:
+// An upload reserved in between the computation and the correction is lost
+// from the usage until the next run, which is why this is only run rarely.
+func (c *TenantControllerImpl) RecalculateUsage(ctx context.Context) (int, error) {
+	currents, err := c.TenantStorer.ListUsage(ctx)
+	if err != nil {
+		c.Logger.Error("database list usage error", slog.Any("error", err))
+		return 0, err
+	}
+	fileUsages, err := c.ObjectFileStorer.ListUsageGroupedByTenantID(ctx)
+	if err != nil {
+		c.Logger.Error("database list usage grouped by tenant id error", slog.Any("error", err))
+		return 0, err
+	}
+	sessionUsages, err := c.UploadSessionStorer.ListActiveUsageGroupedByTenantID(ctx)
+	if err != nil {
+		c.Logger.Error("database list active usage grouped by tenant id error", slog.Any("error", err))
+		return 0, err
+	}
+
+	// Start every tenant with a usage from zero so the tenants whose object
+	// files were all purged are reset.
+	computed := map[primitive.ObjectID]*tenant_s.TenantUsage{}
+	current := map[primitive.ObjectID]*tenant_s.TenantUsage{}
+	usage := func(id primitive.ObjectID) *tenant_s.TenantUsage {
+		u, ok := computed[id]
+		if !ok {
+			u = &tenant_s.TenantUsage{TenantID: id}
+			computed[id] = u
+		}
+		return u
+	}
+	for _, u := range currents {
+		current[u.TenantID] = u
+		usage(u.TenantID)
+	}
+	for _, fu := range fileUsages {
+		u := usage(fu.TenantID)
+		u.UsedInBytes += fu.UsedInBytes
+		u.FileCount += fu.FileCount
+	}
+	for _, su := range sessionUsages {
+		u := usage(su.TenantID)
+		u.UsedInBytes += su.UsedInBytes
+		u.FileCount += su.FileCount
+	}
+
+	corrected := 0
+	for id, u := range computed {
+		cu, ok := current[id]
+		if !ok {
+			cu = &tenant_s.TenantUsage{TenantID: id}
+		}
+		if cu.UsedInBytes == u.UsedInBytes && cu.FileCount == u.FileCount {
+			continue
+		}
+		c.Logger.Warn("correcting tenant usage",
+			slog.Any("tenant_id", id),
+			slog.Int64("used_in_bytes", cu.UsedInBytes),
+			slog.Int64("computed_used_in_bytes", u.UsedInBytes),
+			slog.Int64("file_count", cu.FileCount),
+			slog.Int64("computed_file_count", u.FileCount))
+		if err := c.TenantStorer.SetUsage(ctx, id, u.UsedInBytes, u.FileCount); err != nil {
+			c.Logger.Error("database set usage error", slog.Any("error", err))
+			return corrected, err
+		}
+		corrected++
+	}
+	return corrected, nil
+}
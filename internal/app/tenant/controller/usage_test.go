@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	objectfile_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	uploadsession_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
+)
+
+// fakeTenantStorer keeps the usage of the tenants in memory.
+type fakeTenantStorer struct {
+	tenant_s.TenantStorer
+	usages map[primitive.ObjectID]*tenant_s.TenantUsage
+}
+
+func (s *fakeTenantStorer) ListUsage(ctx context.Context) ([]*tenant_s.TenantUsage, error) {
+	var res []*tenant_s.TenantUsage
+	for _, u := range s.usages {
+		res = append(res, u)
+	}
+	return res, nil
+}
+
+func (s *fakeTenantStorer) SetUsage(ctx context.Context, id primitive.ObjectID, bytes int64, files int64) error {
+	s.usages[id] = &tenant_s.TenantUsage{TenantID: id, UsedInBytes: bytes, FileCount: files}
+	return nil
+}
+
+type fakeObjectFileStorer struct {
+	objectfile_s.ObjectFileStorer
+	usages []*objectfile_s.ObjectFileUsage
+}
+
+func (s *fakeObjectFileStorer) ListUsageGroupedByTenantID(ctx context.Context) ([]*objectfile_s.ObjectFileUsage, error) {
+	return s.usages, nil
+}
+
+type fakeUploadSessionStorer struct {
+	uploadsession_s.UploadSessionStorer
+	usages []*uploadsession_s.UploadSessionUsage
+}
+
+func (s *fakeUploadSessionStorer) ListActiveUsageGroupedByTenantID(ctx context.Context) ([]*uploadsession_s.UploadSessionUsage, error) {
+	return s.usages, nil
+}
+
+func TestRecalculateUsage(t *testing.T) {
+	uploading := primitive.NewObjectID()
+	purged := primitive.NewObjectID()
+	intact := primitive.NewObjectID()
+	tenants := &fakeTenantStorer{usages: map[primitive.ObjectID]*tenant_s.TenantUsage{
+		uploading: {TenantID: uploading, UsedInBytes: 1, FileCount: 1},
+		purged:    {TenantID: purged, UsedInBytes: 7, FileCount: 2},
+		intact:    {TenantID: intact, UsedInBytes: 3, FileCount: 1},
+	}}
+	c := &TenantControllerImpl{
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		TenantStorer: tenants,
+		ObjectFileStorer: &fakeObjectFileStorer{usages: []*objectfile_s.ObjectFileUsage{
+			{TenantID: uploading, UsedInBytes: 1, FileCount: 1},
+			{TenantID: intact, UsedInBytes: 3, FileCount: 1},
+		}},
+		UploadSessionStorer: &fakeUploadSessionStorer{usages: []*uploadsession_s.UploadSessionUsage{
+			{TenantID: uploading, UsedInBytes: 10, FileCount: 1},
+		}},
+	}
+
+	corrected, err := c.RecalculateUsage(context.Background())
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	if corrected != 2 {
+		t.Errorf("expected 2 corrected tenants but received %d", corrected)
+	}
+	if u := tenants.usages[uploading]; u.UsedInBytes != 11 || u.FileCount != 2 {
+		t.Errorf("expected the reservations of the upload sessions to be kept but received %+v", u)
+	}
+	if u := tenants.usages[purged]; u.UsedInBytes != 0 || u.FileCount != 0 {
+		t.Errorf("expected the usage of the tenant without files to be reset but received %+v", u)
+	}
+}
@@ -66,6 +66,15 @@ type Tenant struct {
 	Comments                []*TenantComment   `bson:"comments" json:"comments"`
 	OpenAIAPIKey            string             `bson:"openai_api_key" json:"openai_api_key"`
 	OpenAIOrgKey            string             `bson:"openai_org_key" json:"openai_org_key"`
+
+	// The storage quota of the tenant where zero means unlimited. The usage
+	// is kept separately, see `TenantUsage`.
+	StorageQuotaInBytes int64 `bson:"storage_quota_in_bytes" json:"storage_quota_in_bytes"`
+	FileCountQuota      int64 `bson:"file_count_quota" json:"file_count_quota"`
+
+	// If true the users of the tenant must verify their email address before
+	// they may upload files or share them.
+	RequireVerifiedEmail bool `bson:"require_verified_email" json:"require_verified_email"`
 }
 
 type TenantComment struct {
@@ -126,12 +135,18 @@ type TenantStorer interface {
 	ListByFilter(ctx context.Context, m *TenantListFilter) (*TenantListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *TenantListFilter) ([]*TenantAsSelectOption, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	GetUsageByID(ctx context.Context, id primitive.ObjectID) (*TenantUsage, error)
+	ReserveUsage(ctx context.Context, t *Tenant, bytes int64, files int64) (bool, error)
+	AdjustUsage(ctx context.Context, id primitive.ObjectID, bytes int64, files int64) error
+	SetUsage(ctx context.Context, id primitive.ObjectID, bytes int64, files int64) error
+	ListUsage(ctx context.Context) ([]*TenantUsage, error)
 }
 
 type TenantStorerImpl struct {
-	Logger     *slog.Logger
-	DbClient   *mongo.Client
-	Collection *mongo.Collection
+	Logger          *slog.Logger
+	DbClient        *mongo.Client
+	Collection      *mongo.Collection
+	UsageCollection *mongo.Collection
 }
 
 func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) TenantStorer {
@@ -154,9 +169,10 @@ func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) Te
 	}
 
 	s := &TenantStorerImpl{
-		Logger:     loggerp,
-		DbClient:   client,
-		Collection: uc,
+		Logger:          loggerp,
+		DbClient:        client,
+		Collection:      uc,
+		UsageCollection: client.Database(appCfg.DB.Name).Collection("tenant_usages"),
 	}
 	return s
 }
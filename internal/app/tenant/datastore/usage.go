@@ -0,0 +1,128 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TenantUsage represents the storage used by the object files of a tenant.
+//
+// DEVELOPERS NOTE:
+// The usage is kept in its own collection, and not on the `Tenant`, because
+// `UpdateByID` overwrites the whole tenant which would lose the usage counted
+// by concurrent uploads.
+type TenantUsage struct {
+	TenantID    primitive.ObjectID `bson:"_id" json:"tenant_id"`
+	UsedInBytes int64              `bson:"used_in_bytes" json:"used_in_bytes"`
+	FileCount   int64              `bson:"file_count" json:"file_count"`
+	ModifiedAt  time.Time          `bson:"modified_at" json:"modified_at"`
+}
+
+// GetUsageByID function returns the usage of the tenant; a tenant which never
+// uploaded anything has no usage.
+func (impl TenantStorerImpl) GetUsageByID(ctx context.Context, id primitive.ObjectID) (*TenantUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result TenantUsage
+	err := impl.UsageCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return &TenantUsage{TenantID: id}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ReserveUsage function adds the bytes and files to the usage of the tenant
+// only if the quota of the tenant is not exceeded by doing so. Returns false
+// if the quota would be exceeded.
+//
+// DEVELOPERS NOTE:
+// The quota is checked by the update itself so concurrent uploads cannot
+// exceed it together. When the filter does not match an existing record the
+// upsert collides on the `_id` which tells us the quota would be exceeded.
+func (impl TenantStorerImpl) ReserveUsage(ctx context.Context, t *Tenant, bytes int64, files int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if (t.StorageQuotaInBytes > 0 && bytes > t.StorageQuotaInBytes) || (t.FileCountQuota > 0 && files > t.FileCountQuota) {
+		return false, nil
+	}
+
+	conditions := bson.A{}
+	if t.StorageQuotaInBytes > 0 {
+		conditions = append(conditions, bson.M{"$lte": bson.A{bson.M{"$add": bson.A{"$used_in_bytes", bytes}}, t.StorageQuotaInBytes}})
+	}
+	if t.FileCountQuota > 0 {
+		conditions = append(conditions, bson.M{"$lte": bson.A{bson.M{"$add": bson.A{"$file_count", files}}, t.FileCountQuota}})
+	}
+	filter := bson.M{"_id": t.ID}
+	if len(conditions) > 0 {
+		filter["$expr"] = bson.M{"$and": conditions}
+	}
+	update := bson.M{
+		"$inc": bson.M{"used_in_bytes": bytes, "file_count": files},
+		"$set": bson.M{"modified_at": time.Now()},
+	}
+
+	_, err := impl.UsageCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AdjustUsage function adds the bytes and files, which may be negative, to
+// the usage of the tenant regardless of its quota.
+func (impl TenantStorerImpl) AdjustUsage(ctx context.Context, id primitive.ObjectID, bytes int64, files int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$inc": bson.M{"used_in_bytes": bytes, "file_count": files},
+		"$set": bson.M{"modified_at": time.Now()},
+	}
+	_, err := impl.UsageCollection.UpdateOne(ctx, bson.M{"_id": id}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// SetUsage function overwrites the usage of the tenant, used for correcting
+// the usage from the object files.
+func (impl TenantStorerImpl) SetUsage(ctx context.Context, id primitive.ObjectID, bytes int64, files int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{"used_in_bytes": bytes, "file_count": files, "modified_at": time.Now()},
+	}
+	_, err := impl.UsageCollection.UpdateOne(ctx, bson.M{"_id": id}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// ListUsage function returns the usage of every tenant which has a usage.
+func (impl TenantStorerImpl) ListUsage(ctx context.Context) ([]*TenantUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	cur, err := impl.UsageCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var usages []*TenantUsage
+	if err := cur.All(ctx, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
@@ -0,0 +1,110 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+const (
+	StatusActive    = 1 // Chunks are being uploaded.
+	StatusCompleted = 2 // The object file was created.
+	StatusClaimed   = 3 // A request is completing or aborting the upload session.
+)
+
+// UploadSession represents a resumable upload of a file in numbered chunks.
+// Every chunk is uploaded as a part of a multipart upload in the object
+// storage so a dropped connection only loses the chunk being uploaded.
+type UploadSession struct {
+	ID                primitive.ObjectID `bson:"_id" json:"id"`
+	TenantID          primitive.ObjectID `bson:"tenant_id" json:"tenant_id"`
+	TenantName        string             `bson:"tenant_name" json:"tenant_name"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	CreatedByUserName string             `bson:"created_by_user_name" json:"created_by_user_name"`
+	CreatedByUserID   primitive.ObjectID `bson:"created_by_user_id" json:"created_by_user_id"`
+	ModifiedAt        time.Time          `bson:"modified_at" json:"modified_at"`
+	Status            int8               `bson:"status" json:"status"`
+
+	// The details of the object file to create once completed.
+	Name           string             `bson:"name" json:"name"`
+	Description    string             `bson:"description" json:"description"`
+	FileName       string             `bson:"file_name" json:"file_name"`
+	FileType       string             `bson:"file_type" json:"file_type"`
+	SmartFolderID  primitive.ObjectID `bson:"smart_folder_id" json:"smart_folder_id"`
+	Classification uint64             `bson:"classification" json:"classification"`
+	AllowDuplicate bool               `bson:"allow_duplicate" json:"allow_duplicate"`
+
+	// Size is the total size of the file and every chunk, except the last
+	// one, must be exactly `ChunkSize` bytes.
+	Size       int64                          `bson:"size" json:"size"`
+	ChunkSize  int64                          `bson:"chunk_size" json:"chunk_size"`
+	ChunkCount int32                          `bson:"chunk_count" json:"chunk_count"`
+	Chunks     map[string]*UploadSessionChunk `bson:"chunks" json:"-"` // Keyed by the chunk number.
+
+	ObjectKey string `bson:"object_key" json:"-"` // Hidden from public.
+	UploadID  string `bson:"upload_id" json:"-"`  // Hidden from public.
+
+	// Assembled is true once the chunks were assembled into the object in
+	// the object storage, after which no more chunks are accepted.
+	Assembled bool `bson:"assembled" json:"assembled"`
+
+	// ExpiresAt is extended whenever a chunk is uploaded; sessions without
+	// activity until then are considered abandoned and are cleaned up.
+	ExpiresAt    time.Time          `bson:"expires_at" json:"expires_at"`
+	ObjectFileID primitive.ObjectID `bson:"object_file_id,omitempty" json:"object_file_id,omitempty"`
+}
+
+// UploadSessionChunk represents a chunk received by the upload session.
+type UploadSessionChunk struct {
+	Number     int32     `bson:"number" json:"number"`
+	Size       int64     `bson:"size" json:"size"`
+	ETag       string    `bson:"etag" json:"-"` // Hidden from public.
+	UploadedAt time.Time `bson:"uploaded_at" json:"uploaded_at"`
+}
+
+// UploadSessionStorer Interface for upload session.
+type UploadSessionStorer interface {
+	Create(ctx context.Context, m *UploadSession) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*UploadSession, error)
+	UpdateByID(ctx context.Context, m *UploadSession) error
+	SetChunk(ctx context.Context, id primitive.ObjectID, chunk *UploadSessionChunk, expiresAt time.Time) error
+	UpdateStatusByID(ctx context.Context, id primitive.ObjectID, from int8, to int8) (bool, error)
+	DeleteByID(ctx context.Context, id primitive.ObjectID) error
+	ListExpired(ctx context.Context, before time.Time, limit int64) ([]*UploadSession, error)
+	ListActiveUsageGroupedByTenantID(ctx context.Context) ([]*UploadSessionUsage, error)
+}
+
+type UploadSessionStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) UploadSessionStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("upload_sessions")
+
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &UploadSessionStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
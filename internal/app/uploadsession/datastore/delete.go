@@ -0,0 +1,13 @@
+package datastore
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl UploadSessionStorerImpl) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
+	_, err := impl.Collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
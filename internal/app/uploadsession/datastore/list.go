@@ -0,0 +1,33 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListExpired function returns the upload sessions, of every tenant, which
+// expired before the time.
+func (impl UploadSessionStorerImpl) ListExpired(ctx context.Context, before time.Time, limit int64) ([]*UploadSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
+	defer cancel()
+
+	filter := bson.M{"expires_at": bson.M{"$lte": before}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "expires_at", Value: 1}}).
+		SetLimit(limit)
+
+	cur, err := impl.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var sessions []*UploadSession
+	if err := cur.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
@@ -0,0 +1,64 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (impl UploadSessionStorerImpl) UpdateByID(ctx context.Context, m *UploadSession) error {
+	filter := bson.D{{Key: "_id", Value: m.ID}}
+
+	update := bson.M{ // DEVELOPERS NOTE: https://stackoverflow.com/a/60946010
+		"$set": m,
+	}
+
+	if _, err := impl.Collection.UpdateOne(ctx, filter, update); err != nil {
+		impl.Logger.Error("database update by id error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// UpdateStatusByID function changes the status of the upload session only if
+// the upload session still has the `from` status and returns whether it did.
+// The check and the change are a single operation so two requests racing to
+// complete the same upload session cannot both succeed.
+func (impl UploadSessionStorerImpl) UpdateStatusByID(ctx context.Context, id primitive.ObjectID, from int8, to int8) (bool, error) {
+	filter := bson.M{"_id": id, "status": from}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      to,
+			"modified_at": time.Now(),
+		},
+	}
+	res, err := impl.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database update status by id error", slog.Any("error", err))
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// SetChunk function records the chunk, replacing a previous upload of the
+// same chunk, and extends the expiry of the session. Only the chunk is
+// written so chunks uploaded concurrently do not overwrite each other.
+func (impl UploadSessionStorerImpl) SetChunk(ctx context.Context, id primitive.ObjectID, chunk *UploadSessionChunk, expiresAt time.Time) error {
+	filter := bson.M{"_id": id, "status": StatusActive}
+	update := bson.M{
+		"$set": bson.M{
+			fmt.Sprintf("chunks.%d", chunk.Number): chunk,
+			"modified_at":                          chunk.UploadedAt,
+			"expires_at":                           expiresAt,
+		},
+	}
+	if _, err := impl.Collection.UpdateOne(ctx, filter, update); err != nil {
+		impl.Logger.Error("database set chunk error", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UploadSessionUsage represents the storage reserved by the active upload
+// sessions of a tenant.
+type UploadSessionUsage struct {
+	TenantID    primitive.ObjectID `bson:"_id" json:"tenant_id"`
+	UsedInBytes int64              `bson:"used_in_bytes" json:"used_in_bytes"`
+	FileCount   int64              `bson:"file_count" json:"file_count"`
+}
+
+// ListActiveUsageGroupedByTenantID function returns the storage reserved by
+// the active, or claimed, upload sessions of every tenant which has any.
+func (impl UploadSessionStorerImpl) ListActiveUsageGroupedByTenantID(ctx context.Context) ([]*UploadSessionUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$in": bson.A{StatusActive, StatusClaimed}}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$tenant_id",
+			"used_in_bytes": bson.M{"$sum": "$size"},
+			"file_count":    bson.M{"$sum": 1},
+		}}},
+	}
+	cur, err := impl.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var usages []*UploadSessionUsage
+	if err := cur.All(ctx, &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
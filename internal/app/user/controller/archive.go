@@ -7,6 +7,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"log/slog"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
@@ -38,6 +40,7 @@ func (impl *UserControllerImpl) ArchiveByID(ctx context.Context, id primitive.Ob
 		return nil, httperror.NewForForbiddenWithSingleField("role", "root user(s) cannot be deleted")
 	}
 
+	before := auditevent_c.Snapshot(ou)
 	ou.ModifiedAt = time.Now()
 	ou.Status = user_s.UserStatusArchived
 
@@ -45,5 +48,20 @@ func (impl *UserControllerImpl) ArchiveByID(ctx context.Context, id primitive.Ob
 		impl.Logger.Error("user update by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionArchive,
+		TargetType: auditevent_s.TargetTypeUser,
+		TargetID:   ou.ID,
+		TargetName: ou.Name,
+		Before:     before,
+		After:      ou,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	// Sign out the archived user from every device.
+	if err := impl.Session.SyncByUserID(ctx, ou.ID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return nil, err
+	}
 	return ou, nil
 }
@@ -8,8 +8,11 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/templatedemailer"
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	session_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/controller"
 	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/controller"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/kmutex"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/password"
@@ -22,6 +25,7 @@ type UserController interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*user_s.User, error)
 	GetUserBySessionUUID(ctx context.Context, sessionUUID string) (*user_s.User, error)
 	ArchiveByID(ctx context.Context, id primitive.ObjectID) (*user_s.User, error)
+	ResetOTPByID(ctx context.Context, id primitive.ObjectID) (*user_s.User, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 	ListByFilter(ctx context.Context, f *user_s.UserListFilter) (*user_s.UserListResult, error)
 	ListAsSelectOptionByFilter(ctx context.Context, f *user_s.UserListFilter) ([]*user_s.UserAsSelectOption, error)
@@ -40,6 +44,9 @@ type UserControllerImpl struct {
 	TenantStorer     tenant_s.TenantStorer
 	UserStorer       user_s.UserStorer
 	TemplatedEmailer templatedemailer.TemplatedEmailer
+	AuditEvent       auditevent_c.AuditEventController
+	Session          session_c.SessionController
+	UserToken        usertoken_c.UserTokenController
 }
 
 func NewController(
@@ -52,6 +59,9 @@ func NewController(
 	org_storer tenant_s.TenantStorer,
 	usr_storer user_s.UserStorer,
 	temailer templatedemailer.TemplatedEmailer,
+	ae_controller auditevent_c.AuditEventController,
+	sess_controller session_c.SessionController,
+	ut_controller usertoken_c.UserTokenController,
 ) UserController {
 	s := &UserControllerImpl{
 		Config:           appCfg,
@@ -63,6 +73,9 @@ func NewController(
 		TenantStorer:     org_storer,
 		UserStorer:       usr_storer,
 		TemplatedEmailer: temailer,
+		AuditEvent:       ae_controller,
+		Session:          sess_controller,
+		UserToken:        ut_controller,
 	}
 	s.Logger.Debug("user controller initialization started...")
 
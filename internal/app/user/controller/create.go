@@ -10,6 +10,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
@@ -165,6 +167,15 @@ func (impl *UserControllerImpl) Create(ctx context.Context, requestData *UserCre
 		impl.Logger.Error("failed sending verification email with error", slog.Any("err", err))
 		return nil, err
 	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionCreate,
+		TargetType: auditevent_s.TargetTypeUser,
+		TargetID:   m.ID,
+		TargetName: m.Name,
+		After:      m,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
 
 	return m, nil
 }
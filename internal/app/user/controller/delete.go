@@ -3,6 +3,8 @@ package controller
 import (
 	"context"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
@@ -41,5 +43,19 @@ func (impl *UserControllerImpl) DeleteByID(ctx context.Context, id primitive.Obj
 		impl.Logger.Error("database delete by id error", slog.Any("error", err))
 		return err
 	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionDelete,
+		TargetType: auditevent_s.TargetTypeUser,
+		TargetID:   user.ID,
+		TargetName: user.Name,
+		Before:     user,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	// Sign out the deleted user from every device.
+	if err := impl.Session.SyncByUserID(ctx, id); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return err
+	}
 	return nil
 }
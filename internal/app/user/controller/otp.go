@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/policy"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// ResetOTPByID function turns off 2FA for a user of the tenant who lost
+// access to their device so they may log in with their password and setup
+// 2FA again.
+func (impl *UserControllerImpl) ResetOTPByID(ctx context.Context, id primitive.ObjectID) (*user_s.User, error) {
+	// Extract from our session the following data.
+	userRole := ctx.Value(constants.SessionUserRole).(int8)
+
+	// Apply filtering based on ownership and role.
+	if userRole != user_s.UserRoleExecutive {
+		return nil, httperror.NewForForbiddenWithSingleField("message", "you do not have permission")
+	}
+
+	// Lookup the user in our database, else return a `400 Bad Request` error.
+	ou, err := impl.UserStorer.GetByID(ctx, id)
+	if err != nil {
+		impl.Logger.Error("database error", slog.Any("err", err))
+		return nil, err
+	}
+	if ou == nil {
+		impl.Logger.Warn("user does not exist validation error")
+		return nil, httperror.NewForBadRequestWithSingleField("id", "does not exist")
+	}
+	if err := policy.AuthorizeTenant(ctx, ou.TenantID); err != nil {
+		impl.Logger.Warn("user does not belong to tenant", slog.Any("id", ou.ID))
+		return nil, err
+	}
+	if !ou.OTPEnabled {
+		return nil, httperror.NewForBadRequestWithSingleField("otp_enabled", "two-factor authentication is not setup")
+	}
+
+	before := auditevent_c.Snapshot(ou)
+	ou.OTPEnabled = false
+	ou.OTPVerified = false
+	ou.OTPValidated = false
+	ou.OTPSecret = ""
+	ou.OTPAuthURL = ""
+	ou.OTPRecoveryCodeHashes = nil
+	ou.ModifiedAt = time.Now()
+
+	if err := impl.UserStorer.UpdateByID(ctx, ou); err != nil {
+		impl.Logger.Error("user update by id error", slog.Any("error", err))
+		return nil, err
+	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionResetOTP,
+		TargetType: auditevent_s.TargetTypeUser,
+		TargetID:   ou.ID,
+		TargetName: ou.Name,
+		Before:     before,
+		After:      ou,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	// Sign out the user from every device, otherwise a session which did not
+	// pass 2FA yet would be let in without it.
+	if _, err := impl.Session.RevokeOthersByUserID(ctx, ou.ID, ""); err != nil {
+		impl.Logger.Error("session revoke others by user id error", slog.Any("error", err))
+		return nil, err
+	}
+	return ou, nil
+}
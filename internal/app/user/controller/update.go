@@ -8,7 +8,10 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	auditevent_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	auditevent_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
 )
@@ -125,11 +128,18 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 		return nil, httperror.NewForBadRequestWithSingleField("tenant_id", "tenant does not exist")
 	}
 
+	before := auditevent_c.Snapshot(ou)
 	ou.TenantID = o.ID
+	ou.TenantName = o.Name
 	ou.FirstName = nu.FirstName
 	ou.LastName = nu.LastName
 	ou.Name = fmt.Sprintf("%s %s", nu.FirstName, nu.LastName)
 	ou.LexicalName = fmt.Sprintf("%s, %s", nu.LastName, nu.FirstName)
+	emailChanged := ou.Email != nu.Email
+	if emailChanged {
+		// The new email address must be verified again.
+		ou.WasEmailVerified = false
+	}
 	ou.Email = nu.Email
 	ou.Phone = nu.Phone
 	ou.Country = nu.Country
@@ -157,5 +167,33 @@ func (impl *UserControllerImpl) UpdateByID(ctx context.Context, requestData *Use
 		impl.Logger.Error("user update by id error", slog.Any("error", err))
 		return nil, err
 	}
+	if emailChanged {
+		// Codes emailed to the previous email address stop working.
+		for _, purpose := range []string{usertoken_s.PurposeEmailVerification, usertoken_s.PurposePasswordReset} {
+			if err := impl.UserToken.InvalidateByUserID(ctx, ou.ID, purpose); err != nil {
+				return nil, err
+			}
+		}
+		if err := impl.UserToken.SendVerificationEmail(ctx, ou); err != nil {
+			impl.Logger.Error("failed sending verification email with error from user update",
+				slog.Any("err", err),
+				slog.Any("UserID", ou.ID))
+		}
+	}
+	if err := impl.AuditEvent.Record(ctx, &auditevent_c.AuditEventRecordRequestIDO{
+		Action:     auditevent_s.ActionUpdate,
+		TargetType: auditevent_s.TargetTypeUser,
+		TargetID:   ou.ID,
+		TargetName: ou.Name,
+		Before:     before,
+		After:      ou,
+	}); err != nil {
+		impl.Logger.Warn("failed recording audit event", slog.Any("error", err))
+	}
+	// Apply the change to the signed in sessions of the user immediately.
+	if err := impl.Session.SyncByUserID(ctx, ou.ID); err != nil {
+		impl.Logger.Error("session sync by user id error", slog.Any("error", err))
+		return nil, err
+	}
 	return ou, nil
 }
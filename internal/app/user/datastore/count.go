@@ -10,7 +10,7 @@ import (
 )
 
 func (impl UserStorerImpl) CountByFilter(ctx context.Context, f *UserListFilter) (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 12*time.Second)
 	defer cancel()
 
 	// Create the filter based on the cursor
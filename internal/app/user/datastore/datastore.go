@@ -129,6 +129,11 @@ type User struct {
 
 	// OTPAuthURL is the URL used to share.
 	OTPAuthURL string `bson:"otp_auth_url" json:"-"`
+
+	// OTPRecoveryCodeHashes are the hashes of the unused one-time recovery
+	// codes the user may enter instead of a 2FA token if they lost their
+	// device.
+	OTPRecoveryCodeHashes []string `bson:"otp_recovery_code_hashes" json:"-"`
 }
 
 type UserOTPInput struct {
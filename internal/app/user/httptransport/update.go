@@ -143,3 +143,21 @@ func MarshalUpdateResponse(res *usr_s.User, w http.ResponseWriter) {
 		return
 	}
 }
+
+func (h *Handler) ResetOTPByID(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	m, err := h.Controller.ResetOTPByID(ctx, objectID)
+	if err != nil {
+		httperror.ResponseError(w, err)
+		return
+	}
+
+	MarshalDetailResponse(m, w)
+}
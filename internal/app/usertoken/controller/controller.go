@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/templatedemailer"
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+// UserTokenController Interface for the codes emailed to users to prove they
+// own their email address.
+type UserTokenController interface {
+	Issue(ctx context.Context, u *user_s.User, purpose string, expiry time.Duration) (string, error)
+	Consume(ctx context.Context, purpose string, token string) (*usertoken_s.UserToken, error)
+	InvalidateByUserID(ctx context.Context, userID primitive.ObjectID, purpose string) error
+	SendVerificationEmail(ctx context.Context, u *user_s.User) error
+}
+
+type UserTokenControllerImpl struct {
+	Config           *config.Conf
+	Logger           *slog.Logger
+	TemplatedEmailer templatedemailer.TemplatedEmailer
+	UserTokenStorer  usertoken_s.UserTokenStorer
+}
+
+func NewController(
+	appCfg *config.Conf,
+	loggerp *slog.Logger,
+	te templatedemailer.TemplatedEmailer,
+	ut_storer usertoken_s.UserTokenStorer,
+) UserTokenController {
+	s := &UserTokenControllerImpl{
+		Config:           appCfg,
+		Logger:           loggerp,
+		TemplatedEmailer: te,
+		UserTokenStorer:  ut_storer,
+	}
+	s.Logger.Debug("user token controller initialization started...")
+	s.Logger.Debug("user token controller initialized")
+	return s
+}
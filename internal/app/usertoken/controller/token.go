@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+const (
+	emailVerificationTokenExpiry = 72 * time.Hour
+
+	// At most `userTokenIssueLimit` codes of the same purpose are emailed to
+	// an email address per `userTokenIssueWindow`.
+	userTokenIssueLimit  = 3
+	userTokenIssueWindow = time.Hour
+)
+
+// ErrRateLimited is returned when too many codes were emailed to the email
+// address recently.
+var ErrRateLimited = errors.New("too many codes issued")
+
+// hashUserToken function returns the hash of the code which is stored
+// instead of the code.
+func hashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Issue function returns a new code for the user to prove they own their
+// email address. Codes issued earlier for the same purpose stop working.
+func (impl *UserTokenControllerImpl) Issue(ctx context.Context, u *user_s.User, purpose string, expiry time.Duration) (string, error) {
+	count, err := impl.UserTokenStorer.CountByEmailSince(ctx, purpose, u.Email, time.Now().Add(-userTokenIssueWindow))
+	if err != nil {
+		return "", err
+	}
+	if count >= userTokenIssueLimit {
+		impl.Logger.Warn("user token issuance rate limited", slog.Any("user_id", u.ID), slog.String("purpose", purpose))
+		return "", ErrRateLimited
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		impl.Logger.Error("failed generating user token", slog.Any("err", err))
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+
+	if err := impl.InvalidateByUserID(ctx, u.ID, purpose); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	if err := impl.UserTokenStorer.Create(ctx, &usertoken_s.UserToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    u.ID,
+		Email:     u.Email,
+		Purpose:   purpose,
+		TokenHash: hashUserToken(token),
+		Status:    usertoken_s.StatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(expiry),
+	}); err != nil {
+		impl.Logger.Error("user token create error", slog.Any("err", err))
+		return "", err
+	}
+	return token, nil
+}
+
+// Consume function returns the token of the code and marks it as used so it
+// cannot be used again.
+func (impl *UserTokenControllerImpl) Consume(ctx context.Context, purpose string, token string) (*usertoken_s.UserToken, error) {
+	t, err := impl.UserTokenStorer.GetByTokenHash(ctx, purpose, hashUserToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		impl.Logger.Warn("user token does not exist", slog.String("purpose", purpose))
+		return nil, httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
+	}
+	used, err := impl.UserTokenStorer.MarkUsedByID(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		impl.Logger.Warn("user token expired or used", slog.Any("user_id", t.UserID), slog.String("purpose", purpose))
+		return nil, httperror.NewForBadRequestWithSingleField("code", "expired or invalid")
+	}
+	return t, nil
+}
+
+// InvalidateByUserID function stops the pending codes of the user for the
+// purpose from working.
+func (impl *UserTokenControllerImpl) InvalidateByUserID(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	if _, err := impl.UserTokenStorer.InvalidateByUserID(ctx, userID, purpose); err != nil {
+		impl.Logger.Error("user token invalidate by user id error", slog.Any("err", err))
+		return err
+	}
+	return nil
+}
+
+// SendVerificationEmail function emails a new verification code to the email
+// address of the user.
+func (impl *UserTokenControllerImpl) SendVerificationEmail(ctx context.Context, u *user_s.User) error {
+	code, err := impl.Issue(ctx, u, usertoken_s.PurposeEmailVerification, emailVerificationTokenExpiry)
+	if err != nil {
+		return err
+	}
+	return impl.TemplatedEmailer.SendVerificationEmail(u.Email, code, u.FirstName)
+}
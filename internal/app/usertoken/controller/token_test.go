@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	usertoken_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
+)
+
+// fakeUserTokenStorer keeps the tokens in memory.
+type fakeUserTokenStorer struct {
+	usertoken_s.UserTokenStorer
+	tokens []*usertoken_s.UserToken
+}
+
+func (s *fakeUserTokenStorer) Create(ctx context.Context, m *usertoken_s.UserToken) error {
+	s.tokens = append(s.tokens, m)
+	return nil
+}
+
+func (s *fakeUserTokenStorer) GetByTokenHash(ctx context.Context, purpose string, tokenHash string) (*usertoken_s.UserToken, error) {
+	for _, t := range s.tokens {
+		if t.Purpose == purpose && t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeUserTokenStorer) MarkUsedByID(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	for _, t := range s.tokens {
+		if t.ID == id && t.Status == usertoken_s.StatusPending && time.Now().Before(t.ExpiresAt) {
+			t.Status = usertoken_s.StatusUsed
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeUserTokenStorer) InvalidateByUserID(ctx context.Context, userID primitive.ObjectID, purpose string) (int64, error) {
+	var count int64
+	for _, t := range s.tokens {
+		if t.UserID == userID && t.Purpose == purpose && t.Status == usertoken_s.StatusPending {
+			t.Status = usertoken_s.StatusInvalidated
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeUserTokenStorer) CountByEmailSince(ctx context.Context, purpose string, email string, since time.Time) (int64, error) {
+	var count int64
+	for _, t := range s.tokens {
+		if t.Purpose == purpose && t.Email == email && !t.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newUserTokenTestController() *UserTokenControllerImpl {
+	return &UserTokenControllerImpl{
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		UserTokenStorer: &fakeUserTokenStorer{},
+	}
+}
+
+func TestUserTokenIsSingleUse(t *testing.T) {
+	c := newUserTokenTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID(), Email: "staff@example.com"}
+
+	first, err := c.Issue(ctx, u, usertoken_s.PurposePasswordReset, time.Hour)
+	if err != nil {
+		t.Fatalf("received an error %v", err)
+	}
+	second, _ := c.Issue(ctx, u, usertoken_s.PurposePasswordReset, time.Hour)
+
+	if _, err := c.Consume(ctx, usertoken_s.PurposePasswordReset, first); err == nil {
+		t.Errorf("expected the code to stop working once a newer code was issued")
+	}
+	if _, err := c.Consume(ctx, usertoken_s.PurposeEmailVerification, second); err == nil {
+		t.Errorf("expected the code to only work for its purpose")
+	}
+	tok, err := c.Consume(ctx, usertoken_s.PurposePasswordReset, second)
+	if err != nil || tok.UserID != u.ID {
+		t.Fatalf("expected the code of the user but received %v", err)
+	}
+	if _, err := c.Consume(ctx, usertoken_s.PurposePasswordReset, second); err == nil {
+		t.Errorf("expected the code to be single-use")
+	}
+}
+
+func TestUserTokenIssuanceIsRateLimited(t *testing.T) {
+	c := newUserTokenTestController()
+	ctx := context.Background()
+	u := &user_s.User{ID: primitive.NewObjectID(), Email: "staff@example.com"}
+
+	for i := 0; i < userTokenIssueLimit; i++ {
+		if _, err := c.Issue(ctx, u, usertoken_s.PurposePasswordReset, time.Hour); err != nil {
+			t.Fatalf("received an error %v", err)
+		}
+	}
+	if _, err := c.Issue(ctx, u, usertoken_s.PurposePasswordReset, time.Hour); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected rate limited error but received %v", err)
+	}
+}
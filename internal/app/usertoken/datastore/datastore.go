@@ -0,0 +1,80 @@
+package datastore
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
+)
+
+const (
+	PurposePasswordReset     = "password_reset"
+	PurposeEmailVerification = "email_verification"
+
+	StatusPending     = 1 // The token was emailed and can be used.
+	StatusUsed        = 2 // The token was used.
+	StatusInvalidated = 3 // A newer token was issued or the password was changed.
+)
+
+// UserToken represents a code emailed to a user to prove they own the email
+// address. Only the SHA-256 hash of the code is stored so a leaked database
+// does not leak usable codes.
+type UserToken struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Email     string             `bson:"email" json:"email"`
+	Purpose   string             `bson:"purpose" json:"purpose"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	Status    int8               `bson:"status" json:"status"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	UsedAt    time.Time          `bson:"used_at,omitempty" json:"used_at,omitempty"`
+}
+
+// UserTokenStorer Interface for user token.
+type UserTokenStorer interface {
+	Create(ctx context.Context, m *UserToken) error
+	GetByTokenHash(ctx context.Context, purpose string, tokenHash string) (*UserToken, error)
+	MarkUsedByID(ctx context.Context, id primitive.ObjectID) (bool, error)
+	InvalidateByUserID(ctx context.Context, userID primitive.ObjectID, purpose string) (int64, error)
+	CountByEmailSince(ctx context.Context, purpose string, email string, since time.Time) (int64, error)
+}
+
+type UserTokenStorerImpl struct {
+	Logger     *slog.Logger
+	DbClient   *mongo.Client
+	Collection *mongo.Collection
+}
+
+func NewDatastore(appCfg *c.Conf, loggerp *slog.Logger, client *mongo.Client) UserTokenStorer {
+	// ctx := context.Background()
+	uc := client.Database(appCfg.DB.Name).Collection("user_tokens")
+
+	_, err := uc.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "purpose", Value: 1}}},
+		{Keys: bson.D{{Key: "email", Value: 1}, {Key: "purpose", Value: 1}, {Key: "created_at", Value: -1}}},
+		// Expired tokens are kept for a day to count towards the issuance
+		// limit and then removed by the database.
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60)},
+	})
+	if err != nil {
+		// It is important that we crash the app on startup to meet the
+		// requirements of `google/wire` framework.
+		log.Fatal(err)
+	}
+
+	s := &UserTokenStorerImpl{
+		Logger:     loggerp,
+		DbClient:   client,
+		Collection: uc,
+	}
+	return s
+}
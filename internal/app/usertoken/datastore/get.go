@@ -0,0 +1,45 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (impl UserTokenStorerImpl) GetByTokenHash(ctx context.Context, purpose string, tokenHash string) (*UserToken, error) {
+	filter := bson.M{"purpose": purpose, "token_hash": tokenHash}
+
+	var result UserToken
+	err := impl.Collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// This error means your query did not match any documents.
+			return nil, nil
+		}
+		impl.Logger.Error("database get by token hash error", slog.Any("error", err))
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CountByEmailSince function returns the number of tokens issued to the email
+// since the time, used to limit how often codes are emailed.
+func (impl UserTokenStorerImpl) CountByEmailSince(ctx context.Context, purpose string, email string, since time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"purpose":    purpose,
+		"email":      email,
+		"created_at": bson.M{"$gte": since},
+	}
+	count, err := impl.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		impl.Logger.Error("database count by email since error", slog.Any("error", err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,52 @@
+package datastore
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MarkUsedByID function marks the token as used only if it is still pending
+// and unexpired, and returns whether it did. The check and the change are a
+// single operation so a token cannot be used twice by concurrent requests.
+func (impl UserTokenStorerImpl) MarkUsedByID(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":        id,
+		"status":     StatusPending,
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"status": StatusUsed, "used_at": now}}
+	res, err := impl.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database mark used by id error", slog.Any("error", err))
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// InvalidateByUserID function invalidates the pending tokens of the user for
+// the purpose and returns how many were invalidated.
+func (impl UserTokenStorerImpl) InvalidateByUserID(ctx context.Context, userID primitive.ObjectID, purpose string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"user_id": userID,
+		"purpose": purpose,
+		"status":  StatusPending,
+	}
+	update := bson.M{"$set": bson.M{"status": StatusInvalidated}}
+	res, err := impl.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		impl.Logger.Error("database invalidate by user id error", slog.Any("error", err))
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
@@ -33,6 +33,8 @@ type serverConf struct {
 	HasDebugging            bool
 	DomainName              string
 	Enable2FAOnRegistration bool
+	TrashRetentionInDays    int
+	MaxUploadSizeInMB       int
 }
 
 type dbConfig struct {
@@ -76,6 +78,8 @@ func New() *Conf {
 	c.AppServer.HasDebugging = getEnvBool("NONPROFITVAULT_BACKEND_HAS_DEBUGGING", true, true)
 	c.AppServer.DomainName = getEnv("NONPROFITVAULT_BACKEND_DOMAIN_NAME", true)
 	c.AppServer.Enable2FAOnRegistration = getEnvBool("NONPROFITVAULT_BACKEND_APP_ENABLE_2FA_ON_REGISTRATION", false, false)
+	c.AppServer.TrashRetentionInDays = getEnvInt("NONPROFITVAULT_BACKEND_APP_TRASH_RETENTION_IN_DAYS", false, 30)
+	c.AppServer.MaxUploadSizeInMB = getEnvInt("NONPROFITVAULT_BACKEND_APP_MAX_UPLOAD_SIZE_IN_MB", false, 5120)
 
 	c.DB.URI = getEnv("NONPROFITVAULT_BACKEND_DB_URI", true)
 	c.DB.Name = getEnv("NONPROFITVAULT_BACKEND_DB_NAME", true)
@@ -118,6 +122,18 @@ func getEnvBool(key string, required bool, defaultValue bool) bool {
 	return value
 }
 
+func getEnvInt(key string, required bool, defaultValue int) int {
+	valueStr := getEnv(key, required)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Fatalf("Invalid integer value for environment variable %s", key)
+	}
+	return value
+}
+
 func getObjectIDEnv(key string, required bool) primitive.ObjectID {
 	value := os.Getenv(key)
 	if required && value == "" {
@@ -21,4 +21,6 @@ const (
 	SessionUserTenantID
 	SessionUserTenantName
 	SessionUserOTPValidated
+	SessionUserAgent
+	SessionUserWasEmailVerified
 )
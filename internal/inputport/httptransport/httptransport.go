@@ -7,9 +7,12 @@ import (
 
 	"github.com/rs/cors"
 
+	auditevent "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/httptransport"
 	gateway "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/httptransport"
 	howhear "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/httptransport"
+	job "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/httptransport"
 	objectfile "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/httptransport"
+	session "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/httptransport"
 	sl_http "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/httptransport"
 	sf_http "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/httptransport"
 	tenant "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/httptransport"
@@ -35,6 +38,9 @@ type httpTransportInputPort struct {
 	ObjectFile    *objectfile.Handler
 	SmartFolder   *sf_http.Handler
 	ShareableLink *sl_http.Handler
+	AuditEvent    *auditevent.Handler
+	Job           *job.Handler
+	Session       *session.Handler
 }
 
 func NewInputPort(
@@ -48,6 +54,9 @@ func NewInputPort(
 	att *objectfile.Handler,
 	sf *sf_http.Handler,
 	sl *sl_http.Handler,
+	ae *auditevent.Handler,
+	jb *job.Handler,
+	sess *session.Handler,
 ) InputPortServer {
 	// Initialize the ServeMux.
 	mux := http.NewServeMux()
@@ -76,6 +85,9 @@ func NewInputPort(
 		ObjectFile:    att,
 		SmartFolder:   sf,
 		ShareableLink: sl,
+		AuditEvent:    ae,
+		Job:           jb,
+		Session:       sess,
 		Server:        srv,
 	}
 
@@ -127,8 +139,8 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.Gateway.UserRegister(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "refresh-token" && r.Method == http.MethodPost:
 		port.Gateway.RefreshToken(w, r)
-	// case n == 3 && p[1] == "v1" && p[2] == "verify" && r.Method == http.MethodPost:
-	// 	port.Gateway.Verify(w, r)
+	case n == 3 && p[1] == "v1" && p[2] == "verify" && r.Method == http.MethodPost:
+		port.Gateway.VerifyEmail(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "logout" && r.Method == http.MethodPost:
 		port.Gateway.Logout(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "profile" && r.Method == http.MethodGet:
@@ -137,6 +149,12 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.Gateway.ProfileUpdate(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "profile" && p[3] == "change-password" && r.Method == http.MethodPut:
 		port.Gateway.ProfileChangePassword(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "profile" && p[3] == "resend-verification" && r.Method == http.MethodPost:
+		port.Gateway.ResendVerificationEmail(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "profile" && p[3] == "sessions" && r.Method == http.MethodGet:
+		port.Session.ListByProfile(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "profile" && p[3] == "session" && r.Method == http.MethodDelete:
+		port.Session.RevokeByProfile(w, r, p[4])
 	case n == 3 && p[1] == "v1" && p[2] == "forgot-password" && r.Method == http.MethodPost:
 		port.Gateway.ForgotPassword(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "password-reset" && r.Method == http.MethodPost:
@@ -154,6 +172,8 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.Gateway.ValidateOTP(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "otp" && p[3] == "disable" && r.Method == http.MethodPost:
 		port.Gateway.DisableOTP(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "otp" && p[3] == "generate-recovery-codes" && r.Method == http.MethodPost:
+		port.Gateway.GenerateOTPRecoveryCodes(w, r)
 
 	// // --- DASHBOARD --- //
 	// case n == 3 && p[1] == "v1" && p[2] == "dashboard" && r.Method == http.MethodGet:
@@ -171,6 +191,8 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.Tenant.UpdateByID(w, r, p[3])
 	case n == 4 && p[1] == "v1" && p[2] == "tenant" && r.Method == http.MethodDelete:
 		port.Tenant.DeleteByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "tenant" && p[4] == "usage" && r.Method == http.MethodGet:
+		port.Tenant.GetUsageByID(w, r, p[3])
 	case n == 5 && p[1] == "v1" && p[2] == "tenants" && p[3] == "operation" && p[4] == "create-comment" && r.Method == http.MethodPost:
 		port.Tenant.OperationCreateComment(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "tenants" && p[3] == "select-options" && r.Method == http.MethodGet:
@@ -207,6 +229,10 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.User.UpdateByID(w, r, p[3])
 	case n == 4 && p[1] == "v1" && p[2] == "user" && r.Method == http.MethodDelete:
 		port.User.DeleteByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "user" && p[4] == "sign-out-everywhere" && r.Method == http.MethodPost:
+		port.Session.RevokeAllByUserID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "user" && p[4] == "reset-otp" && r.Method == http.MethodPost:
+		port.User.ResetOTPByID(w, r, p[3])
 	case n == 5 && p[1] == "v1" && p[2] == "users" && p[3] == "operation" && p[4] == "create-comment" && r.Method == http.MethodPost:
 		port.User.OperationCreateComment(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "users" && p[3] == "select-options" && r.Method == http.MethodGet:
@@ -225,12 +251,24 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.SmartFolder.DeleteByID(w, r, p[3])
 	case n == 5 && p[1] == "v1" && p[2] == "smart-folders" && p[3] == "operations" && p[4] == "generate-shareable-link" && r.Method == http.MethodPost:
 		port.SmartFolder.GenerateShareableLink(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "smart-folder" && p[4] == "archive" && r.Method == http.MethodGet:
+		port.SmartFolder.GetArchiveByID(w, r, p[3])
+	case n == 4 && p[1] == "v1" && p[2] == "smart-folders" && p[3] == "trash" && r.Method == http.MethodGet:
+		port.SmartFolder.ListTrash(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "smart-folder" && p[4] == "restore" && r.Method == http.MethodPost:
+		port.SmartFolder.RestoreByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "smart-folder" && p[4] == "purge" && r.Method == http.MethodDelete:
+		port.SmartFolder.PurgeByID(w, r, p[3])
 
 	// --- OBJECT FILES --- //
 	case n == 3 && p[1] == "v1" && p[2] == "object-files" && r.Method == http.MethodGet:
 		port.ObjectFile.List(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "object-files" && r.Method == http.MethodPost:
 		port.ObjectFile.Create(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "object-files" && p[3] == "operation" && p[4] == "migrate-keys" && r.Method == http.MethodPost:
+		port.ObjectFile.MigrateObjectKeys(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "object-files" && p[3] == "operation" && p[4] == "reconcile-storage" && r.Method == http.MethodPost:
+		port.ObjectFile.ReconcileStorage(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "object-file" && r.Method == http.MethodGet:
 		port.ObjectFile.GetByID(w, r, p[3])
 	case n == 4 && p[1] == "v1" && p[2] == "object-file" && r.Method == http.MethodPut:
@@ -241,22 +279,66 @@ func (port *httpTransportInputPort) HandleRequests(w http.ResponseWriter, r *htt
 		port.ObjectFile.GetPresignedURLByID(w, r, p[3])
 	case n == 5 && p[1] == "v1" && p[2] == "object-file" && p[4] == "content" && r.Method == http.MethodGet:
 		port.ObjectFile.GetContentByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "object-file" && p[4] == "versions" && r.Method == http.MethodGet:
+		port.ObjectFile.ListVersionsByID(w, r, p[3])
+	case n == 7 && p[1] == "v1" && p[2] == "object-file" && p[4] == "version" && p[6] == "content" && r.Method == http.MethodGet:
+		port.ObjectFile.GetVersionContentByID(w, r, p[3], p[5])
+	case n == 7 && p[1] == "v1" && p[2] == "object-file" && p[4] == "version" && p[6] == "restore" && r.Method == http.MethodPost:
+		port.ObjectFile.RestoreVersionByID(w, r, p[3], p[5])
+	case n == 4 && p[1] == "v1" && p[2] == "object-files" && p[3] == "trash" && r.Method == http.MethodGet:
+		port.ObjectFile.ListTrash(w, r)
+	case n == 5 && p[1] == "v1" && p[2] == "object-file" && p[4] == "restore" && r.Method == http.MethodPost:
+		port.ObjectFile.RestoreByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "object-file" && p[4] == "purge" && r.Method == http.MethodDelete:
+		port.ObjectFile.PurgeByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "object-file" && p[4] == "retry-upload" && r.Method == http.MethodPost:
+		port.ObjectFile.RetryUploadByID(w, r, p[3])
+	case n == 3 && p[1] == "v1" && p[2] == "upload-sessions" && r.Method == http.MethodPost:
+		port.ObjectFile.InitiateUploadSession(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "upload-session" && r.Method == http.MethodGet:
+		port.ObjectFile.GetUploadSessionByID(w, r, p[3])
+	case n == 4 && p[1] == "v1" && p[2] == "upload-session" && r.Method == http.MethodDelete:
+		port.ObjectFile.AbortUploadSession(w, r, p[3])
+	case n == 6 && p[1] == "v1" && p[2] == "upload-session" && p[4] == "chunk" && r.Method == http.MethodPut:
+		port.ObjectFile.UploadChunk(w, r, p[3], p[5])
+	case n == 5 && p[1] == "v1" && p[2] == "upload-session" && p[4] == "complete" && r.Method == http.MethodPost:
+		port.ObjectFile.CompleteUploadSession(w, r, p[3])
 
-	// --- SMART FOLDERS --- //
-	// case n == 3 && p[1] == "v1" && p[2] == "smart-folders" && r.Method == http.MethodGet:
-	// 	port.SmartFolder.List(w, r)
+	// --- SHAREABLE LINKS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "shareable-links" && r.Method == http.MethodGet:
+		port.ShareableLink.List(w, r)
 	case n == 3 && p[1] == "v1" && p[2] == "shareable-links" && r.Method == http.MethodPost:
 		port.ShareableLink.Create(w, r)
 	case n == 4 && p[1] == "v1" && p[2] == "shareable-link" && r.Method == http.MethodGet:
 		port.ShareableLink.GetByID(w, r, p[3])
-	// case n == 4 && p[1] == "v1" && p[2] == "smart-folder" && r.Method == http.MethodPut:
-	// 	port.SmartFolder.UpdateByID(w, r, p[3])
-	// case n == 4 && p[1] == "v1" && p[2] == "smart-folder" && r.Method == http.MethodDelete:
-	// 	port.SmartFolder.DeleteByID(w, r, p[3])
-	// case n == 5 && p[1] == "v1" && p[2] == "smart-folders" && p[3] == "operations" && p[4] == "generate-shareable-link" && r.Method == http.MethodPost:
-	// 	port.SmartFolder.GenerateShareableLink(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "shareable-link" && r.Method == http.MethodPut:
+		port.ShareableLink.UpdateByID(w, r, p[3])
+	case n == 4 && p[1] == "v1" && p[2] == "shareable-link" && r.Method == http.MethodDelete:
+		port.ShareableLink.DeleteByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "shareable-link" && p[4] == "archive" && r.Method == http.MethodPost:
+		port.ShareableLink.ArchiveByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "shareable-link" && p[4] == "revoke" && r.Method == http.MethodPost:
+		port.ShareableLink.RevokeByID(w, r, p[3])
+	case n == 5 && p[1] == "v1" && p[2] == "shareable-link" && p[4] == "accesses" && r.Method == http.MethodGet:
+		port.ShareableLink.ListAccessesByID(w, r, p[3])
 	case n == 5 && p[1] == "v1" && p[2] == "public" && p[3] == "shareable-link" && r.Method == http.MethodGet:
 		port.ShareableLink.PublicGetByID(w, r, p[4])
+	case n == 6 && p[1] == "v1" && p[2] == "public" && p[3] == "shareable-link" && p[5] == "archive" && r.Method == http.MethodGet:
+		port.ShareableLink.PublicGetArchive(w, r, p[4])
+	case n == 8 && p[1] == "v1" && p[2] == "public" && p[3] == "shareable-link" && p[5] == "object-file" && p[7] == "content" && r.Method == http.MethodGet:
+		port.ShareableLink.PublicGetObjectFileContent(w, r, p[4], p[6])
+	case n == 8 && p[1] == "v1" && p[2] == "public" && p[3] == "shareable-link" && p[5] == "object-file" && p[7] == "presigned-url" && r.Method == http.MethodGet:
+		port.ShareableLink.PublicGetObjectFilePresignedURL(w, r, p[4], p[6])
+
+	// --- AUDIT EVENTS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "audit-events" && r.Method == http.MethodGet:
+		port.AuditEvent.List(w, r)
+	case n == 4 && p[1] == "v1" && p[2] == "audit-events" && p[3] == "export" && r.Method == http.MethodGet:
+		port.AuditEvent.ExportCSV(w, r)
+
+	// --- JOBS --- //
+	case n == 3 && p[1] == "v1" && p[2] == "jobs" && r.Method == http.MethodGet:
+		port.Job.List(w, r)
 
 	// --- CATCH ALL: D.N.E. ---
 	default:
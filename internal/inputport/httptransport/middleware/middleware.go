@@ -151,6 +151,7 @@ func (mid *middleware) PreJWTProcessorMiddleware(fn http.HandlerFunc) http.Handl
 			"forgot-password": true,
 			"password-reset":  true,
 			"select-options":  true,
+			"public":          true,
 		}
 
 		// DEVELOPERS NOTE:
@@ -243,6 +244,7 @@ func (mid *middleware) JWTProcessorMiddleware(fn http.HandlerFunc) http.HandlerF
 				"forgot-password": true,
 				"password-reset":  true,
 				"select-options":  true,
+				"public":          true,
 			}
 
 			// DEVELOPERS NOTE:
@@ -362,6 +364,7 @@ func (mid *middleware) PostJWTProcessorMiddleware(fn http.HandlerFunc) http.Hand
 			ctx = context.WithValue(ctx, constants.SessionUserTenantID, user.TenantID)
 			ctx = context.WithValue(ctx, constants.SessionUserTenantName, user.TenantName)
 			ctx = context.WithValue(ctx, constants.SessionUserOTPValidated, user.OTPValidated)
+			ctx = context.WithValue(ctx, constants.SessionUserWasEmailVerified, user.WasEmailVerified)
 		}
 
 		fn(w, r.WithContext(ctx))
@@ -379,9 +382,10 @@ func (mid *middleware) IPAddressMiddleware(fn http.HandlerFunc) http.HandlerFunc
 			IPAddress = r.RemoteAddr
 		}
 
-		// Save our IP address to the context.
+		// Save our IP address and user agent to the context.
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, constants.SessionIPAddress, IPAddress)
+		ctx = context.WithValue(ctx, constants.SessionUserAgent, r.UserAgent())
 		fn(w, r.WithContext(ctx)) // Flow to the next middleware.
 	}
 }
@@ -416,6 +420,7 @@ func (mid *middleware) ProtectedURLsMiddleware(fn http.HandlerFunc) http.Handler
 			"forgot-password": true,
 			"password-reset":  true,
 			"select-options":  true,
+			"public":          true,
 		}
 
 		// DEVELOPERS NOTE:
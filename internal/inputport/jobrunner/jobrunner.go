@@ -0,0 +1,97 @@
+package jobrunner
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	gateway_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/controller"
+	job_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/controller"
+	objectfile_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	shareablelink_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/controller"
+	smartfolder_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/controller"
+	tenant_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/controller"
+)
+
+// checkInterval is how often every job is checked to see if it is due. The
+// job itself only runs once per its own interval across the cluster.
+const checkInterval = time.Minute
+
+type InputPortServer interface {
+	Run()
+	Shutdown()
+}
+
+type jobRunnerInputPort struct {
+	Logger        *slog.Logger
+	Job           job_c.JobController
+	Gateway       gateway_c.GatewayController
+	SmartFolder   smartfolder_c.SmartFolderController
+	ObjectFile    objectfile_c.ObjectFileController
+	ShareableLink shareablelink_c.ShareableLinkController
+	Tenant        tenant_c.TenantController
+	Jobs          []*job_c.ScheduledJob
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+func NewInputPort(
+	loggerp *slog.Logger,
+	jc job_c.JobController,
+	gc gateway_c.GatewayController,
+	sf smartfolder_c.SmartFolderController,
+	of objectfile_c.ObjectFileController,
+	sl shareablelink_c.ShareableLinkController,
+	tc tenant_c.TenantController,
+) InputPortServer {
+	ctx, cancel := context.WithCancel(context.Background())
+	port := &jobRunnerInputPort{
+		Logger:        loggerp,
+		Job:           jc,
+		Gateway:       gc,
+		SmartFolder:   sf,
+		ObjectFile:    of,
+		ShareableLink: sl,
+		Tenant:        tc,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	port.Jobs = port.scheduledJobs()
+	return port
+}
+
+func (port *jobRunnerInputPort) Run() {
+	port.Logger.Info("job runner running", slog.Int("jobs", len(port.Jobs)))
+	for _, j := range port.Jobs {
+		port.wg.Add(1)
+		go port.loop(j)
+	}
+}
+
+func (port *jobRunnerInputPort) Shutdown() {
+	// Wait for the running jobs to stop so their outcome gets recorded.
+	port.cancel()
+	port.wg.Wait()
+	port.Logger.Info("job runner shutdown")
+}
+
+// loop runs the job whenever it is due until the application shuts down.
+func (port *jobRunnerInputPort) loop(j *job_c.ScheduledJob) {
+	defer port.wg.Done()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		// Errors were logged by the controller and recorded on the job.
+		_, _ = port.Job.RunIfDue(port.ctx, j)
+
+		select {
+		case <-port.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
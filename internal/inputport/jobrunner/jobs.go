@@ -0,0 +1,84 @@
+package jobrunner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	job_c "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/controller"
+)
+
+// scheduledJobs returns the maintenance jobs run in the background.
+func (port *jobRunnerInputPort) scheduledJobs() []*job_c.ScheduledJob {
+	return []*job_c.ScheduledJob{
+		{
+			Name:     "expire-shareable-links",
+			Interval: 15 * time.Minute,
+			Timeout:  5 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				count, err := port.ShareableLink.ExpireOverdue(ctx)
+				return fmt.Sprintf("expired %d shareable links", count), err
+			},
+		},
+		{
+			Name:     "purge-expired-sessions",
+			Interval: time.Hour,
+			Timeout:  5 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				count, err := port.Gateway.PurgeExpiredSessions(ctx)
+				return fmt.Sprintf("purged %d sessions", count), err
+			},
+		},
+		{
+			Name:     "purge-expired-trash",
+			Interval: time.Hour,
+			Timeout:  30 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				// Purge the smart folders first so the object files trashed
+				// along with them are removed together with their folder.
+				sfCount, err := port.SmartFolder.PurgeExpiredTrash(ctx)
+				if err != nil {
+					return fmt.Sprintf("purged %d smart folders", sfCount), err
+				}
+				ofCount, err := port.ObjectFile.PurgeExpiredTrash(ctx)
+				return fmt.Sprintf("purged %d smart folders and %d object files", sfCount, ofCount), err
+			},
+		},
+		{
+			Name:     "recover-stalled-uploads",
+			Interval: 15 * time.Minute,
+			Timeout:  10 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				recovered, failed, err := port.ObjectFile.RecoverStalledUploads(ctx)
+				return fmt.Sprintf("recovered %d uploads and marked %d as failed", recovered, failed), err
+			},
+		},
+		{
+			Name:     "cleanup-upload-sessions",
+			Interval: time.Hour,
+			Timeout:  10 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				count, err := port.ObjectFile.CleanupAbandonedUploadSessions(ctx)
+				return fmt.Sprintf("cleaned up %d upload sessions", count), err
+			},
+		},
+		{
+			Name:     "reconcile-storage",
+			Interval: 24 * time.Hour,
+			Timeout:  time.Hour,
+			Run: func(ctx context.Context) (string, error) {
+				missing, orphaned, err := port.ObjectFile.ReconcileAllStorage(ctx)
+				return fmt.Sprintf("found %d missing and %d orphaned objects", missing, orphaned), err
+			},
+		},
+		{
+			Name:     "recalculate-tenant-usage",
+			Interval: 24 * time.Hour,
+			Timeout:  30 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				count, err := port.Tenant.RecalculateUsage(ctx)
+				return fmt.Sprintf("corrected the usage of %d tenants", count), err
+			},
+		},
+	}
+}
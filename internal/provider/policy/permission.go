@@ -0,0 +1,128 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+const (
+	PermissionView   int8 = 1 // View smart folders, object files and their content.
+	PermissionUpload int8 = 2 // Upload new object files.
+	PermissionEdit   int8 = 3 // Modify smart folders and object files.
+	PermissionShare  int8 = 4 // Manage shareable links and review their accesses.
+	PermissionDelete int8 = 5 // Delete smart folders and object files.
+)
+
+// permissionNames is used for the error messages.
+var permissionNames = map[int8]string{
+	PermissionView:   "view",
+	PermissionUpload: "upload",
+	PermissionEdit:   "edit",
+	PermissionShare:  "share",
+	PermissionDelete: "delete",
+}
+
+// rolePermissions is the permission matrix of every role.
+var rolePermissions = map[int8][]int8{
+	user_s.UserRoleExecutive:      {PermissionView, PermissionUpload, PermissionEdit, PermissionShare, PermissionDelete},
+	user_s.UserRoleManagement:     {PermissionView, PermissionUpload, PermissionEdit, PermissionShare, PermissionDelete},
+	user_s.UserRoleFrontlineStaff: {PermissionView, PermissionUpload, PermissionEdit, PermissionShare},
+	user_s.UserRoleAssociate:      {PermissionView, PermissionUpload},
+	user_s.UserRoleCustomer:       {PermissionView},
+}
+
+// AccessControlEntry grants a specific user permissions on a record. Records
+// with at least one entry are restricted to the users listed.
+type AccessControlEntry struct {
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	UserName    string             `bson:"user_name" json:"user_name"`
+	Permissions []int8             `bson:"permissions" json:"permissions"`
+}
+
+// RoleHasPermission returns true if the role grants the permission.
+func RoleHasPermission(role int8, permission int8) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidPermission returns true if the permission exists.
+func IsValidPermission(permission int8) bool {
+	_, ok := permissionNames[permission]
+	return ok
+}
+
+// CanManageAccessControl returns true if the role may change the access
+// control list of records.
+func CanManageAccessControl(role int8) bool {
+	return role == user_s.UserRoleExecutive || role == user_s.UserRoleManagement
+}
+
+// Authorize returns a `403 Forbidden` error if the role of the authenticated
+// user does not grant the permission.
+func Authorize(ctx context.Context, permission int8) error {
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	if !RoleHasPermission(role, permission) {
+		return httperror.NewForForbiddenWithSingleField("message", fmt.Sprintf("your role does not grant you the %s permission", permissionNames[permission]))
+	}
+	return nil
+}
+
+// CanAccessControlList returns true if the access control list of a record
+// grants the permission to the authenticated user. Executives are never
+// restricted as they manage the access control lists of their tenant.
+func CanAccessControlList(ctx context.Context, acl []*AccessControlEntry, permission int8) bool {
+	if len(acl) == 0 {
+		return true
+	}
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	if role == user_s.UserRoleExecutive {
+		return true
+	}
+	userID, _ := ctx.Value(constants.SessionUserID).(primitive.ObjectID)
+	for _, e := range acl {
+		if e.UserID != userID {
+			continue
+		}
+		for _, p := range e.Permissions {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AuthorizeRecord returns a `403 Forbidden` error unless the record belongs to
+// the tenant of the authenticated user, the role of the user grants the
+// permission and the access control list of the record, if any, grants the
+// permission to the user.
+func AuthorizeRecord(ctx context.Context, recordTenantID primitive.ObjectID, acl []*AccessControlEntry, permission int8) error {
+	if err := AuthorizeTenant(ctx, recordTenantID); err != nil {
+		return err
+	}
+	if err := Authorize(ctx, permission); err != nil {
+		return err
+	}
+	if !CanAccessControlList(ctx, acl, permission) {
+		return httperror.NewForForbiddenWithSingleField("message", fmt.Sprintf("you were not granted the %s permission for this record", permissionNames[permission]))
+	}
+	return nil
+}
+
+// IsRestrictedFromListing returns true if list queries must hide the records
+// with access control lists which do not grant the authenticated user the
+// view permission.
+func IsRestrictedFromListing(ctx context.Context) bool {
+	role, _ := ctx.Value(constants.SessionUserRole).(int8)
+	return role != user_s.UserRoleExecutive
+}
@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	user_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+)
+
+func newPermissionTestContext(tenantID, userID primitive.ObjectID, role int8) context.Context {
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, tenantID)
+	ctx = context.WithValue(ctx, constants.SessionUserID, userID)
+	return context.WithValue(ctx, constants.SessionUserRole, role)
+}
+
+func TestRoleHasPermission(t *testing.T) {
+	tests := []struct {
+		role       int8
+		permission int8
+		expected   bool
+	}{
+		{user_s.UserRoleExecutive, PermissionDelete, true},
+		{user_s.UserRoleManagement, PermissionDelete, true},
+		{user_s.UserRoleFrontlineStaff, PermissionShare, true},
+		{user_s.UserRoleFrontlineStaff, PermissionDelete, false},
+		{user_s.UserRoleAssociate, PermissionUpload, true},
+		{user_s.UserRoleAssociate, PermissionEdit, false},
+		{user_s.UserRoleCustomer, PermissionView, true},
+		{user_s.UserRoleCustomer, PermissionUpload, false},
+		{0, PermissionView, false},
+	}
+	for _, tt := range tests {
+		if got := RoleHasPermission(tt.role, tt.permission); got != tt.expected {
+			t.Errorf("role %d permission %d: expected %v but received %v", tt.role, tt.permission, tt.expected, got)
+		}
+	}
+}
+
+func TestAuthorizeRecordWithAccessControlList(t *testing.T) {
+	tenantID := primitive.NewObjectID()
+	boardMember := primitive.NewObjectID()
+	staffMember := primitive.NewObjectID()
+	acl := []*AccessControlEntry{
+		{UserID: boardMember, Permissions: []int8{PermissionView, PermissionEdit}},
+	}
+
+	// Listed users are granted only the listed permissions.
+	ctx := newPermissionTestContext(tenantID, boardMember, user_s.UserRoleManagement)
+	if err := AuthorizeRecord(ctx, tenantID, acl, PermissionView); err != nil {
+		t.Errorf("expected access for listed user but received %v", err)
+	}
+	if err := AuthorizeRecord(ctx, tenantID, acl, PermissionDelete); err == nil {
+		t.Error("expected forbidden error for permission missing from the list")
+	}
+
+	// Unlisted users are denied even if their role allows it.
+	ctx = newPermissionTestContext(tenantID, staffMember, user_s.UserRoleFrontlineStaff)
+	if err := AuthorizeRecord(ctx, tenantID, acl, PermissionView); err == nil {
+		t.Error("expected forbidden error for unlisted user")
+	}
+	if err := AuthorizeRecord(ctx, tenantID, nil, PermissionView); err != nil {
+		t.Errorf("expected access without access control list but received %v", err)
+	}
+
+	// Executives manage the lists and are never restricted by them.
+	ctx = newPermissionTestContext(tenantID, primitive.NewObjectID(), user_s.UserRoleExecutive)
+	if err := AuthorizeRecord(ctx, tenantID, acl, PermissionDelete); err != nil {
+		t.Errorf("expected access for executive but received %v", err)
+	}
+
+	// A listed user cannot exceed the permissions of their role.
+	ctx = newPermissionTestContext(tenantID, boardMember, user_s.UserRoleCustomer)
+	if err := AuthorizeRecord(ctx, tenantID, acl, PermissionEdit); err == nil {
+		t.Error("expected forbidden error for permission missing from the role")
+	}
+}
@@ -0,0 +1,41 @@
+// Package policy is the central place which decides what the authenticated
+// user of a request is allowed to do. Every controller must go through it
+// before returning, sharing, modifying or deleting a tenant's records.
+package policy
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// SessionTenantID returns the tenant of the authenticated user. List queries
+// must always be scoped to this tenant.
+//
+// DEVELOPERS NOTE:
+// Executives are scoped the same as everyone else; to work on another
+// tenant's records they must first visit the tenant which switches the tenant
+// of their session.
+func SessionTenantID(ctx context.Context) primitive.ObjectID {
+	tid, _ := ctx.Value(constants.SessionUserTenantID).(primitive.ObjectID)
+	return tid
+}
+
+// CanAccessTenant returns true if the record of the tenant is accessible by the
+// authenticated user.
+func CanAccessTenant(ctx context.Context, recordTenantID primitive.ObjectID) bool {
+	tid := SessionTenantID(ctx)
+	return !tid.IsZero() && tid == recordTenantID
+}
+
+// AuthorizeTenant returns a `403 Forbidden` error if the record of the tenant
+// is not accessible by the authenticated user.
+func AuthorizeTenant(ctx context.Context, recordTenantID primitive.ObjectID) error {
+	if !CanAccessTenant(ctx, recordTenantID) {
+		return httperror.NewForForbiddenWithSingleField("message", "you do not belong to this tenant")
+	}
+	return nil
+}
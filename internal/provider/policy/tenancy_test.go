@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func TestAuthorizeTenant(t *testing.T) {
+	tenantA := primitive.NewObjectID()
+	tenantB := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, tenantA)
+
+	if err := AuthorizeTenant(ctx, tenantA); err != nil {
+		t.Errorf("expected access to own tenant but received %v", err)
+	}
+
+	err := AuthorizeTenant(ctx, tenantB)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+		t.Errorf("expected forbidden error for another tenant but received %v", err)
+	}
+}
+
+func TestAuthorizeTenantWithoutSession(t *testing.T) {
+	// An unauthenticated context must never match, not even a record which is
+	// missing its tenant.
+	if err := AuthorizeTenant(context.Background(), primitive.NilObjectID); err == nil {
+		t.Error("expected forbidden error for missing session tenant")
+	}
+	if err := AuthorizeTenant(context.Background(), primitive.NewObjectID()); err == nil {
+		t.Error("expected forbidden error for missing session tenant")
+	}
+}
+
+func TestSessionTenantID(t *testing.T) {
+	tenantA := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, tenantA)
+	if got := SessionTenantID(ctx); got != tenantA {
+		t.Errorf("expected %v but received %v", tenantA, got)
+	}
+	if got := SessionTenantID(context.Background()); !got.IsZero() {
+		t.Errorf("expected zero tenant but received %v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+// AuthorizeVerifiedEmail returns a `403 Forbidden` error if the tenant
+// requires its users to verify their email address before uploading or
+// sharing files and the authenticated user did not.
+func AuthorizeVerifiedEmail(ctx context.Context, t *tenant_s.Tenant) error {
+	if t == nil || !t.RequireVerifiedEmail {
+		return nil
+	}
+	if verified, _ := ctx.Value(constants.SessionUserWasEmailVerified).(bool); !verified {
+		return httperror.NewForForbiddenWithSingleField("email", "you must verify your email address first")
+	}
+	return nil
+}
+
+// TenantGetter is the part of the tenant datastore needed to look up the
+// tenant of the authenticated user.
+type TenantGetter interface {
+	GetByID(ctx context.Context, id primitive.ObjectID) (*tenant_s.Tenant, error)
+}
+
+// AuthorizeVerifiedEmailForTenant looks up the tenant of the authenticated
+// user and returns a `403 Forbidden` error if it requires a verified email
+// address which the user did not verify.
+func AuthorizeVerifiedEmailForTenant(ctx context.Context, tenants TenantGetter, logger *slog.Logger) error {
+	tid := SessionTenantID(ctx)
+	t, err := tenants.GetByID(ctx, tid)
+	if err != nil {
+		logger.Error("failed getting tenant", slog.Any("error", err))
+		return err
+	}
+	if err := AuthorizeVerifiedEmail(ctx, t); err != nil {
+		logger.Warn("access denied to unverified email", slog.Any("tenant_id", tid))
+		return err
+	}
+	return nil
+}
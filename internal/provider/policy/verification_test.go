@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	tenant_s "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config/constants"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/utils/httperror"
+)
+
+func TestAuthorizeVerifiedEmail(t *testing.T) {
+	unverified := context.WithValue(context.Background(), constants.SessionUserWasEmailVerified, false)
+	verified := context.WithValue(context.Background(), constants.SessionUserWasEmailVerified, true)
+
+	// Tenants which do not require verification allow everyone.
+	if err := AuthorizeVerifiedEmail(unverified, &tenant_s.Tenant{}); err != nil {
+		t.Errorf("expected access without required verification but received %v", err)
+	}
+
+	required := &tenant_s.Tenant{RequireVerifiedEmail: true}
+	if err := AuthorizeVerifiedEmail(verified, required); err != nil {
+		t.Errorf("expected access with verified email but received %v", err)
+	}
+
+	for _, ctx := range []context.Context{unverified, context.Background()} {
+		err := AuthorizeVerifiedEmail(ctx, required)
+		var httpErr httperror.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+			t.Errorf("expected forbidden error for unverified email but received %v", err)
+		}
+	}
+}
+
+type fakeTenantGetter map[primitive.ObjectID]*tenant_s.Tenant
+
+func (f fakeTenantGetter) GetByID(ctx context.Context, id primitive.ObjectID) (*tenant_s.Tenant, error) {
+	return f[id], nil
+}
+
+func TestAuthorizeVerifiedEmailForTenant(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tid := primitive.NewObjectID()
+	ctx := context.WithValue(context.Background(), constants.SessionUserTenantID, tid)
+	ctx = context.WithValue(ctx, constants.SessionUserWasEmailVerified, false)
+
+	if err := AuthorizeVerifiedEmailForTenant(ctx, fakeTenantGetter{}, logger); err != nil {
+		t.Errorf("expected access without tenant but received %v", err)
+	}
+
+	tenants := fakeTenantGetter{tid: {ID: tid, RequireVerifiedEmail: true}}
+	err := AuthorizeVerifiedEmailForTenant(ctx, tenants, logger)
+	var httpErr httperror.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+		t.Errorf("expected forbidden error for unverified email but received %v", err)
+	}
+}
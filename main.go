@@ -10,21 +10,25 @@ import (
 	_ "go.uber.org/automaxprocs" // Automatically set GOMAXPROCS to match Linux container CPU quota.
 
 	http "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/httptransport"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/jobrunner"
 )
 
 type Application struct {
 	Logger        *slog.Logger
 	HTTPTransport http.InputPortServer
+	JobRunner     jobrunner.InputPortServer
 }
 
 // NewApplication is application construction function which is automatically called by `Google Wire` dependency injection library.
 func NewApplication(
 	loggerp *slog.Logger,
 	httpTransport http.InputPortServer,
+	jobRunner jobrunner.InputPortServer,
 ) Application {
 	return Application{
 		Logger:        loggerp,
 		HTTPTransport: httpTransport,
+		JobRunner:     jobRunner,
 	}
 }
 
@@ -35,6 +39,9 @@ func (a Application) Execute() {
 	// Run in background the HTTP server.
 	go a.HTTPTransport.Run()
 
+	// Run in background the scheduled maintenance jobs.
+	go a.JobRunner.Run()
+
 	a.Logger.Info("Application started")
 
 	// Run the main loop blocking code while other input ports run in background.
@@ -45,6 +52,7 @@ func (a Application) Execute() {
 
 func (a Application) Shutdown() {
 	a.HTTPTransport.Shutdown()
+	a.JobRunner.Shutdown()
 	a.Logger.Info("Application shutdown")
 }
 
@@ -22,24 +22,37 @@ import (
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/time"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/uuid"
 
+	ds_auditevent "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
 	ds_howhear "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/datastore"
+	ds_job "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
 	ds_objectfile "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
+	ds_session "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
 	ds_shareablelink "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
+	ds_shareablelinkaccess "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
 	ds_smartfolder "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	ds_tenant "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
+	ds_uploadsession "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
 	ds_user "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
+	ds_usertoken "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
 
+	uc_auditevent "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
 	uc_gateway "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/controller"
 	uc_howhear "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/controller"
+	uc_job "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/controller"
 	uc_objectfile "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
+	uc_session "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/controller"
 	uc_shareablelink "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/controller"
 	uc_smartfolder "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/controller"
 	uc_tenant "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/controller"
 	uc_user "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/controller"
+	uc_usertoken "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/controller"
 
+	http_auditevent "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/httptransport"
 	http_gate "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/httptransport"
 	http_howhear "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/httptransport"
+	http_job "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/httptransport"
 	http_objectfile "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/httptransport"
+	http_session "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/httptransport"
 	http_shareablelink "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/httptransport"
 	http_smartfolder "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/httptransport"
 	http_tenant "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/httptransport"
@@ -47,6 +60,7 @@ import (
 
 	http "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/httptransport"
 	http_middleware "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/httptransport/middleware"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/jobrunner"
 )
 
 func InitializeEvent() Application {
@@ -81,6 +95,12 @@ func InitializeEvent() Application {
 		ds_objectfile.NewDatastore,
 		ds_smartfolder.NewDatastore,
 		ds_shareablelink.NewDatastore,
+		ds_shareablelinkaccess.NewDatastore,
+		ds_auditevent.NewDatastore,
+		ds_job.NewDatastore,
+		ds_uploadsession.NewDatastore,
+		ds_session.NewDatastore,
+		ds_usertoken.NewDatastore,
 
 		// USECASE
 		uc_tenant.NewController,
@@ -90,6 +110,10 @@ func InitializeEvent() Application {
 		uc_objectfile.NewController,
 		uc_smartfolder.NewController,
 		uc_shareablelink.NewController,
+		uc_auditevent.NewController,
+		uc_job.NewController,
+		uc_session.NewController,
+		uc_usertoken.NewController,
 
 		// HTTP TRANSPORT SECTION
 		http_tenant.NewHandler,
@@ -99,10 +123,14 @@ func InitializeEvent() Application {
 		http_objectfile.NewHandler,
 		http_smartfolder.NewHandler,
 		http_shareablelink.NewHandler,
+		http_auditevent.NewHandler,
+		http_job.NewHandler,
+		http_session.NewHandler,
 
 		// INPUT PORT SECTION
 		http_middleware.NewMiddleware,
 		http.NewInputPort,
+		jobrunner.NewInputPort,
 
 		// APP
 		NewApplication)
@@ -11,29 +11,43 @@ import (
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/emailer/mailgun"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/storage/object"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/adapter/templatedemailer"
+	controller8 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/controller"
+	datastore8 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/datastore"
+	httptransport9 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/auditevent/httptransport"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/controller"
 	httptransport2 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/gateway/httptransport"
 	controller4 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/controller"
 	datastore3 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/datastore"
 	httptransport4 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/howhear/httptransport"
+	controller9 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/controller"
+	datastore9 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/datastore"
+	httptransport10 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/job/httptransport"
 	controller5 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/controller"
 	datastore5 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/datastore"
 	httptransport5 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/objectfile/httptransport"
+	controller10 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/controller"
+	datastore11 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/datastore"
+	httptransport11 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/session/httptransport"
 	controller7 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/controller"
 	datastore6 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/datastore"
 	httptransport7 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelink/httptransport"
+	datastore7 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/shareablelinkaccess/datastore"
 	controller6 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/controller"
 	datastore4 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/datastore"
 	httptransport6 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/smartfolder/httptransport"
 	controller2 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/controller"
 	datastore2 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/tenant/httptransport"
+	datastore10 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/uploadsession/datastore"
 	controller3 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/controller"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/datastore"
 	httptransport3 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/user/httptransport"
+	controller11 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/controller"
+	datastore12 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/app/usertoken/datastore"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/config"
 	httptransport8 "github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/httptransport"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/httptransport/middleware"
+	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/inputport/jobrunner"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/jwt"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/kmutex"
 	"github.com/Pharo-Non-Profit/nonprofitvault-backend/internal/provider/logger"
@@ -65,26 +79,40 @@ func InitializeEvent() Application {
 	userStorer := datastore.NewDatastore(conf, slogLogger, client)
 	tenantStorer := datastore2.NewDatastore(conf, slogLogger, client)
 	howHearAboutUsItemStorer := datastore3.NewDatastore(conf, slogLogger, client)
-	gatewayController := controller.NewController(conf, slogLogger, provider, jwtProvider, passwordProvider, kmutexProvider, cacher, templatedEmailer, client, userStorer, tenantStorer, howHearAboutUsItemStorer)
+	sessionStorer := datastore11.NewDatastore(conf, slogLogger, client)
+	auditEventStorer := datastore8.NewDatastore(conf, slogLogger, client)
+	auditEventController := controller8.NewController(conf, slogLogger, auditEventStorer)
+	sessionController := controller10.NewController(conf, slogLogger, provider, cacher, sessionStorer, userStorer, auditEventController)
+	userTokenStorer := datastore12.NewDatastore(conf, slogLogger, client)
+	userTokenController := controller11.NewController(conf, slogLogger, templatedEmailer, userTokenStorer)
+	gatewayController := controller.NewController(conf, slogLogger, provider, jwtProvider, passwordProvider, kmutexProvider, cacher, templatedEmailer, client, userStorer, tenantStorer, howHearAboutUsItemStorer, sessionController, userTokenController)
 	middlewareMiddleware := middleware.NewMiddleware(conf, slogLogger, provider, timeProvider, jwtProvider, gatewayController)
 	objectStorager := object.NewStorage(conf, slogLogger, provider)
-	tenantController := controller2.NewController(conf, slogLogger, provider, kmutexProvider, objectStorager, emailer, client, tenantStorer)
+	objectFileStorer := datastore5.NewDatastore(conf, slogLogger, client)
+	uploadSessionStorer := datastore10.NewDatastore(conf, slogLogger, client)
+	tenantController := controller2.NewController(conf, slogLogger, provider, kmutexProvider, objectStorager, emailer, client, tenantStorer, objectFileStorer, uploadSessionStorer, auditEventController)
 	handler := httptransport.NewHandler(slogLogger, tenantController)
 	httptransportHandler := httptransport2.NewHandler(slogLogger, gatewayController)
-	userController := controller3.NewController(conf, slogLogger, provider, passwordProvider, kmutexProvider, client, tenantStorer, userStorer, templatedEmailer)
+	userController := controller3.NewController(conf, slogLogger, provider, passwordProvider, kmutexProvider, client, tenantStorer, userStorer, templatedEmailer, auditEventController, sessionController, userTokenController)
 	handler2 := httptransport3.NewHandler(slogLogger, userController)
 	howHearAboutUsItemController := controller4.NewController(conf, slogLogger, provider, objectStorager, passwordProvider, kmutexProvider, templatedEmailer, client, userStorer, howHearAboutUsItemStorer)
 	handler3 := httptransport4.NewHandler(slogLogger, howHearAboutUsItemController)
 	smartFolderStorer := datastore4.NewDatastore(conf, slogLogger, client)
-	objectFileStorer := datastore5.NewDatastore(conf, slogLogger, client)
-	objectFileController := controller5.NewController(conf, slogLogger, provider, objectStorager, client, emailer, smartFolderStorer, objectFileStorer, userStorer)
+	objectFileController := controller5.NewController(conf, slogLogger, provider, objectStorager, client, emailer, smartFolderStorer, objectFileStorer, userStorer, tenantStorer, uploadSessionStorer, auditEventController)
 	handler4 := httptransport5.NewHandler(slogLogger, objectFileController)
-	smartFolderController := controller6.NewController(conf, slogLogger, provider, objectStorager, passwordProvider, kmutexProvider, templatedEmailer, client, userStorer, smartFolderStorer, objectFileStorer)
-	handler5 := httptransport6.NewHandler(slogLogger, smartFolderController)
 	shareableLinkStorer := datastore6.NewDatastore(conf, slogLogger, client)
-	shareableLinkController := controller7.NewController(conf, slogLogger, provider, objectStorager, passwordProvider, kmutexProvider, templatedEmailer, client, userStorer, shareableLinkStorer, smartFolderStorer, objectFileStorer)
+	shareableLinkAccessStorer := datastore7.NewDatastore(conf, slogLogger, client)
+	shareableLinkController := controller7.NewController(conf, slogLogger, provider, objectStorager, passwordProvider, kmutexProvider, templatedEmailer, client, userStorer, shareableLinkStorer, shareableLinkAccessStorer, smartFolderStorer, objectFileStorer, tenantStorer, auditEventController)
+	smartFolderController := controller6.NewController(conf, slogLogger, provider, objectStorager, passwordProvider, kmutexProvider, templatedEmailer, client, userStorer, smartFolderStorer, objectFileStorer, shareableLinkStorer, tenantStorer, auditEventController, shareableLinkController)
+	handler5 := httptransport6.NewHandler(slogLogger, smartFolderController)
 	handler6 := httptransport7.NewHandler(slogLogger, shareableLinkController)
-	inputPortServer := httptransport8.NewInputPort(conf, slogLogger, middlewareMiddleware, handler, httptransportHandler, handler2, handler3, handler4, handler5, handler6)
-	application := NewApplication(slogLogger, inputPortServer)
+	handler7 := httptransport9.NewHandler(slogLogger, auditEventController)
+	jobStorer := datastore9.NewDatastore(conf, slogLogger, client)
+	jobController := controller9.NewController(conf, slogLogger, provider, jobStorer)
+	handler8 := httptransport10.NewHandler(slogLogger, jobController)
+	handler9 := httptransport11.NewHandler(slogLogger, sessionController)
+	inputPortServer := httptransport8.NewInputPort(conf, slogLogger, middlewareMiddleware, handler, httptransportHandler, handler2, handler3, handler4, handler5, handler6, handler7, handler8, handler9)
+	jobrunnerInputPortServer := jobrunner.NewInputPort(slogLogger, jobController, gatewayController, smartFolderController, objectFileController, shareableLinkController, tenantController)
+	application := NewApplication(slogLogger, inputPortServer, jobrunnerInputPortServer)
 	return application
 }